@@ -17,6 +17,8 @@ type Service interface {
 
 	LoginProctor(req *LoginRequest) (*LoginResponse, error)
 	ValidateProctorToken(tokenString string) (*lib.JWTClaims, error)
+	GenerateProctorStreamToken(examSerial string) string
+	ValidateProctorStreamToken(tokenString string, examSerial string) (*lib.JWTClaims, error)
 }
 type service struct {
 	cfg *config.Config
@@ -112,3 +114,42 @@ func (s *service) ValidateProctorToken(tokenString string) (*lib.JWTClaims, erro
 
 	return claims, nil
 }
+
+// GenerateProctorStreamToken issues a short lived token for opening the participant state stream of one exam.
+// EventSource can not send the Authorization header, so this token is passed in the query string instead of the login token.
+func (s *service) GenerateProctorStreamToken(examSerial string) string {
+	claims := lib.JWTClaims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    s.cfg.AuthConfig.ApplicationName,
+			Subject:   examSerial,
+			ExpiresAt: time.Now().Add(s.cfg.AuthConfig.StreamTokenExpirationDuration).Unix(),
+		},
+		Username: constants.ProctorStreamUser,
+	}
+	token := jwt.NewWithClaims(
+		jwt.SigningMethodHS256,
+		claims,
+	)
+	signedToken, _ := token.SignedString(s.cfg.AuthConfig.SignatureKey)
+	return signedToken
+}
+
+func (s *service) ValidateProctorStreamToken(tokenString string, examSerial string) (*lib.JWTClaims, error) {
+	claims := &lib.JWTClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, s.VerifyToken)
+	if err != nil {
+		return nil, lib.ErrUnauthorizedRequest
+	}
+	if !token.Valid {
+		return nil, lib.ErrUnauthorizedRequest
+	}
+	claims, ok := token.Claims.(*lib.JWTClaims)
+	if !ok {
+		return nil, lib.ErrUnauthorizedRequest
+	}
+	if claims.Username != constants.ProctorStreamUser || claims.Subject != examSerial {
+		return nil, lib.ErrUnauthorizedRequest
+	}
+
+	return claims, nil
+}
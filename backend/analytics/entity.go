@@ -0,0 +1,59 @@
+package analytics
+
+import (
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+type ItemAnalysis struct {
+	QuestionID          uint
+	OrderNumber         int
+	ServedCount         int
+	CorrectCount        int
+	PValue              float64 // proportion of served participants who answered correctly
+	DiscriminationIndex float64 // p-value of the upper 27% minus p-value of the lower 27%, ranked by total point
+	PointBiserial       float64 // correlation between answering correctly and the total point
+	BlankCount          int
+	BlankShare          float64
+	Options             []*OptionAnalysis
+}
+
+type OptionAnalysis struct {
+	McqOptionID uint
+	Description string
+	Point       int
+	IsKey       bool
+	ChosenCount int
+	ChosenShare float64
+}
+
+type ExamStatistics struct {
+	SubmittedCount             int
+	Mean                       float64
+	Median                     float64
+	StandardDeviation          float64
+	Min                        int
+	Max                        int
+	Histogram                  []*HistogramBin
+	ItemCount                  int // number of questions served to every submitted participant, used for the reliability
+	KR20                       float64
+	CronbachAlpha              float64
+	StandardErrorOfMeasurement float64
+}
+
+type HistogramBin struct {
+	LowerBound float64
+	UpperBound float64
+	Count      int
+}
+
+type AnswerSimilarityPair struct {
+	lib.BaseModel
+	ExamID                   uint
+	FirstParticipantID       uint
+	SecondParticipantID      uint
+	CommonQuestionCount      int     // questions answered by both participants
+	IdenticalAnswerCount     int     // questions answered with the same option
+	SharedWrongCount         int     // questions answered with the same wrong option
+	ExpectedSharedWrongCount float64 // shared wrong answers expected by chance, from the other participants' choices
+	Score                    float64 // how far SharedWrongCount is above the expectation, in standard deviations
+}
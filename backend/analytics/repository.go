@@ -0,0 +1,86 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	redis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetExamStatisticsByExamID(examID uint) (*ExamStatistics, error)
+	SaveExamStatistics(examID uint, examStatistics *ExamStatistics) error
+	DeleteExamStatisticsByExamID(examID uint) error
+
+	ReplaceAnswerSimilarityPairs(examID uint, answerSimilarityPairs []*AnswerSimilarityPair) error
+	GetAnswerSimilarityPairsByExamID(examID uint) ([]*AnswerSimilarityPair, error)
+}
+
+type repository struct {
+	cfg   *config.Config
+	db    *gorm.DB
+	cache *redis.Client
+}
+
+func NewRepository(
+	cfg *config.Config,
+	db *gorm.DB,
+	cache *redis.Client,
+) Repository {
+	return &repository{
+		cfg:   cfg,
+		db:    db,
+		cache: cache,
+	}
+}
+
+// GetExamStatisticsByExamID only reads the cache, the statistics are computed by the service.
+func (r *repository) GetExamStatisticsByExamID(examID uint) (*ExamStatistics, error) {
+	val, err := r.cache.Get(context.Background(), r.GetExamStatisticsByExamIDCacheKey(examID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var examStatistics ExamStatistics
+	err = json.Unmarshal([]byte(val), &examStatistics)
+	if err != nil {
+		return nil, err
+	}
+	return &examStatistics, nil
+}
+
+func (r *repository) SaveExamStatistics(examID uint, examStatistics *ExamStatistics) error {
+	res, _ := json.Marshal(examStatistics)
+	return r.cache.Set(context.Background(), r.GetExamStatisticsByExamIDCacheKey(examID), res, r.cfg.CacheTTL).Err()
+}
+
+func (r *repository) DeleteExamStatisticsByExamID(examID uint) error {
+	return r.cache.Del(context.Background(), r.GetExamStatisticsByExamIDCacheKey(examID)).Err()
+}
+
+// ReplaceAnswerSimilarityPairs archives the result of the previous detection of the exam and stores the new one.
+func (r *repository) ReplaceAnswerSimilarityPairs(examID uint, answerSimilarityPairs []*AnswerSimilarityPair) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("exam_id = ?", examID).Delete(&AnswerSimilarityPair{}).Error; err != nil {
+			return err
+		}
+		if len(answerSimilarityPairs) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(answerSimilarityPairs, constants.InsertionBatchSize).Error
+	})
+}
+
+func (r *repository) GetAnswerSimilarityPairsByExamID(examID uint) ([]*AnswerSimilarityPair, error) {
+	var res []*AnswerSimilarityPair
+	err := r.db.Where("exam_id = ?", examID).Order("score DESC").Find(&res).Error
+	return res, err
+}
+
+func (r *repository) GetExamStatisticsByExamIDCacheKey(examID uint) string {
+	return fmt.Sprintf("exam_statistics:examID:%d", examID)
+}
@@ -0,0 +1,207 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/client/queue"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/mcqoption"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/question"
+)
+
+// discriminationGroupRatio is the share of participants in each of the upper and lower groups.
+const discriminationGroupRatio = 0.27
+
+type Service interface {
+	GetItemAnalysisByExamID(examID uint) ([]*ItemAnalysis, error)
+	GetExamStatisticsByExamID(examID uint) (*ExamStatistics, error)
+	InvalidateExamStatistics(examID uint)
+	InvalidateExamStatisticsByParticipantID(participantID uint)
+
+	RequestAnswerSimilarityDetection(examID uint) error
+	DetectAnswerSimilarity(examID uint) error
+	GetAnswerSimilarityPairsByExamID(examID uint) ([]*AnswerSimilarityPair, error)
+}
+
+type service struct {
+	cfg                   *config.Config
+	answerSimilarityQueue queue.Queue
+	analyticsRepository   Repository
+	participantService    participant.Service
+	questionService       question.Service
+	mcqOptionService      mcqoption.Service
+}
+
+func NewService(
+	cfg *config.Config,
+	answerSimilarityQueue queue.Queue,
+	analyticsRepository Repository,
+	participantService participant.Service,
+	questionService question.Service,
+	mcqOptionService mcqoption.Service,
+) Service {
+	return &service{
+		cfg:                   cfg,
+		answerSimilarityQueue: answerSimilarityQueue,
+		analyticsRepository:   analyticsRepository,
+		participantService:    participantService,
+		questionService:       questionService,
+		mcqOptionService:      mcqOptionService,
+	}
+}
+
+// GetItemAnalysisByExamID analyzes every question of the exam over the submitted participants it was served to,
+// since the blanks of a participant still working on the exam are not final answers yet.
+func (s *service) GetItemAnalysisByExamID(examID uint) ([]*ItemAnalysis, error) {
+	questions, err := s.questionService.GetQuestionsIDByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := s.participantService.GetParticipantsByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	submittedParticipantIDs := map[uint]bool{}
+	for _, p := range participants {
+		if p.IsSubmitted() {
+			submittedParticipantIDs[p.ID] = true
+		}
+	}
+
+	totalPoints, err := s.participantService.GetParticipantTotalPointsByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	mapTotalPoints := map[uint]float64{}
+	for _, totalPoint := range totalPoints {
+		mapTotalPoints[totalPoint.ParticipantID] = float64(totalPoint.TotalPoint)
+	}
+
+	participantsAnswers, err := s.participantService.GetParticipantsAnswersByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	mapQuestionAnswers := map[uint][]*participant.ParticipantAnswers{}
+	for _, participantAnswer := range participantsAnswers {
+		if !submittedParticipantIDs[participantAnswer.ParticipantID] {
+			continue
+		}
+		mapQuestionAnswers[participantAnswer.QuestionID] = append(mapQuestionAnswers[participantAnswer.QuestionID], participantAnswer)
+	}
+
+	res := []*ItemAnalysis{}
+	for i, q := range questions {
+		mcqOptions, err := s.mcqOptionService.GetMcqOptionsByQuestionID(q.ID)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s.analyzeItem(q.ID, i+1, mcqOptions, mapQuestionAnswers[q.ID], mapTotalPoints))
+	}
+	return res, nil
+}
+
+func (s *service) analyzeItem(questionID uint, orderNumber int, mcqOptions []*mcqoption.McqOption, answers []*participant.ParticipantAnswers, mapTotalPoints map[uint]float64) *ItemAnalysis {
+	res := &ItemAnalysis{
+		QuestionID:  questionID,
+		OrderNumber: orderNumber,
+		ServedCount: len(answers),
+		Options:     []*OptionAnalysis{},
+	}
+
+	chosenCounts := map[uint]int{}
+	for _, answer := range answers {
+		if !answer.IsAnswered {
+			res.BlankCount++
+			continue
+		}
+		chosenCounts[answer.McqOptionID]++
+		if answer.IsCorrect {
+			res.CorrectCount++
+		}
+	}
+
+	for _, mcqOption := range mcqOptions {
+		optionAnalysis := &OptionAnalysis{
+			McqOptionID: mcqOption.ID,
+			Description: mcqOption.Description,
+			Point:       mcqOption.Point,
+			IsKey:       mcqOption.Point > 0,
+			ChosenCount: chosenCounts[mcqOption.ID],
+		}
+		if res.ServedCount > 0 {
+			optionAnalysis.ChosenShare = float64(optionAnalysis.ChosenCount) / float64(res.ServedCount)
+		}
+		res.Options = append(res.Options, optionAnalysis)
+	}
+
+	if res.ServedCount == 0 {
+		return res
+	}
+	res.PValue = float64(res.CorrectCount) / float64(res.ServedCount)
+	res.BlankShare = float64(res.BlankCount) / float64(res.ServedCount)
+	res.DiscriminationIndex = s.discriminationIndex(answers, mapTotalPoints)
+	res.PointBiserial = s.pointBiserial(answers, mapTotalPoints)
+	return res
+}
+
+func (s *service) discriminationIndex(answers []*participant.ParticipantAnswers, mapTotalPoints map[uint]float64) float64 {
+	ranked := make([]*participant.ParticipantAnswers, len(answers))
+	copy(ranked, answers)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return mapTotalPoints[ranked[i].ParticipantID] > mapTotalPoints[ranked[j].ParticipantID]
+	})
+
+	groupSize := int(math.Round(float64(len(ranked)) * discriminationGroupRatio))
+	if groupSize == 0 {
+		groupSize = 1
+	}
+	if groupSize*2 > len(ranked) {
+		groupSize = len(ranked) / 2
+	}
+	if groupSize == 0 {
+		return 0
+	}
+
+	upperCorrect, lowerCorrect := 0, 0
+	for i := 0; i < groupSize; i++ {
+		if ranked[i].IsCorrect {
+			upperCorrect++
+		}
+		if ranked[len(ranked)-1-i].IsCorrect {
+			lowerCorrect++
+		}
+	}
+	return float64(upperCorrect-lowerCorrect) / float64(groupSize)
+}
+
+func (s *service) pointBiserial(answers []*participant.ParticipantAnswers, mapTotalPoints map[uint]float64) float64 {
+	n := float64(len(answers))
+	var sum, sumSquares, sumCorrect float64
+	correctCount := 0
+	for _, answer := range answers {
+		totalPoint := mapTotalPoints[answer.ParticipantID]
+		sum += totalPoint
+		sumSquares += totalPoint * totalPoint
+		if answer.IsCorrect {
+			sumCorrect += totalPoint
+			correctCount++
+		}
+	}
+	if correctCount == 0 || correctCount == len(answers) {
+		return 0
+	}
+
+	mean := sum / n
+	standardDeviation := math.Sqrt(sumSquares/n - mean*mean)
+	if standardDeviation == 0 {
+		return 0
+	}
+
+	p := float64(correctCount) / n
+	meanCorrect := sumCorrect / float64(correctCount)
+	meanIncorrect := (sum - sumCorrect) / (n - float64(correctCount))
+	return (meanCorrect - meanIncorrect) / standardDeviation * math.Sqrt(p*(1-p))
+}
@@ -0,0 +1,144 @@
+package analytics
+
+import (
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+)
+
+// RequestAnswerSimilarityDetection queues the detection, since comparing every pair of participants can take a while.
+func (s *service) RequestAnswerSimilarityDetection(examID uint) error {
+	err := s.answerSimilarityQueue.Publish(strconv.FormatUint(uint64(examID), 10))
+	if err != nil {
+		log.Println("[analytics][service][RequestAnswerSimilarityDetection] failed to publish:", err.Error())
+		return lib.ErrFailedToRequestAnswerSimilarityDetection
+	}
+	return nil
+}
+
+// DetectAnswerSimilarity compares the answers of every pair of started participants of the exam, in the style of the ω index.
+// For each question the first participant answered wrongly, the chance that the second one picks the same wrong option
+// is the share of the other participants who picked it, and the other way around. The pair score is the larger of both
+// z-scores of the observed shared wrong answers against that expectation.
+func (s *service) DetectAnswerSimilarity(examID uint) error {
+	participantsAnswers, err := s.participantService.GetParticipantsAnswersByExamID(examID)
+	if err != nil {
+		return err
+	}
+
+	participantIDs := []uint{}
+	mapParticipantAnswers := map[uint]map[uint]*participant.ParticipantAnswers{}
+	chosenCounts := map[uint]map[uint]int{}
+	servedCounts := map[uint]int{}
+	for _, participantAnswer := range participantsAnswers {
+		if _, ok := mapParticipantAnswers[participantAnswer.ParticipantID]; !ok {
+			mapParticipantAnswers[participantAnswer.ParticipantID] = map[uint]*participant.ParticipantAnswers{}
+			participantIDs = append(participantIDs, participantAnswer.ParticipantID)
+		}
+		mapParticipantAnswers[participantAnswer.ParticipantID][participantAnswer.QuestionID] = participantAnswer
+
+		servedCounts[participantAnswer.QuestionID]++
+		if participantAnswer.IsAnswered {
+			if _, ok := chosenCounts[participantAnswer.QuestionID]; !ok {
+				chosenCounts[participantAnswer.QuestionID] = map[uint]int{}
+			}
+			chosenCounts[participantAnswer.QuestionID][participantAnswer.McqOptionID]++
+		}
+	}
+
+	answerSimilarityPairs := []*AnswerSimilarityPair{}
+	for i := range participantIDs {
+		for j := i + 1; j < len(participantIDs); j++ {
+			pair := s.compareAnswers(mapParticipantAnswers[participantIDs[i]], mapParticipantAnswers[participantIDs[j]], chosenCounts, servedCounts)
+			if pair.Score < s.cfg.AnswerSimilarityMinimumScore {
+				continue
+			}
+			pair.ExamID = examID
+			pair.FirstParticipantID = participantIDs[i]
+			pair.SecondParticipantID = participantIDs[j]
+			answerSimilarityPairs = append(answerSimilarityPairs, pair)
+		}
+	}
+
+	err = s.analyticsRepository.ReplaceAnswerSimilarityPairs(examID, answerSimilarityPairs)
+	if err != nil {
+		log.Println("[analytics][service][DetectAnswerSimilarity] failed to save answer similarity pairs:", err.Error())
+		return lib.ErrFailedToDetectAnswerSimilarity
+	}
+	return nil
+}
+
+func (s *service) GetAnswerSimilarityPairsByExamID(examID uint) ([]*AnswerSimilarityPair, error) {
+	res, err := s.analyticsRepository.GetAnswerSimilarityPairsByExamID(examID)
+	if err != nil {
+		log.Println("[analytics][service][GetAnswerSimilarityPairsByExamID] failed to get answer similarity pairs:", err.Error())
+		return nil, lib.ErrFailedToGetAnswerSimilarityPairs
+	}
+	return res, nil
+}
+
+func (s *service) compareAnswers(first map[uint]*participant.ParticipantAnswers, second map[uint]*participant.ParticipantAnswers, chosenCounts map[uint]map[uint]int, servedCounts map[uint]int) *AnswerSimilarityPair {
+	res := &AnswerSimilarityPair{}
+	var firstExpected, firstVariance, secondExpected, secondVariance float64
+	for questionID, firstAnswer := range first {
+		secondAnswer, ok := second[questionID]
+		if !ok || !firstAnswer.IsAnswered || !secondAnswer.IsAnswered {
+			continue
+		}
+		res.CommonQuestionCount++
+
+		if firstAnswer.McqOptionID == secondAnswer.McqOptionID {
+			res.IdenticalAnswerCount++
+			if !firstAnswer.IsCorrect {
+				res.SharedWrongCount++
+			}
+		}
+
+		// the pair itself is left out of the share of the other participants
+		otherCount := servedCounts[questionID] - 2
+		if otherCount <= 0 {
+			continue
+		}
+		if !firstAnswer.IsCorrect {
+			p := s.otherShare(chosenCounts[questionID][firstAnswer.McqOptionID], firstAnswer, secondAnswer, otherCount)
+			secondExpected += p
+			secondVariance += p * (1 - p)
+		}
+		if !secondAnswer.IsCorrect {
+			p := s.otherShare(chosenCounts[questionID][secondAnswer.McqOptionID], secondAnswer, firstAnswer, otherCount)
+			firstExpected += p
+			firstVariance += p * (1 - p)
+		}
+	}
+
+	// only the shared wrong answers count towards the observation, so both directions observe the same number
+	firstScore := zScore(float64(res.SharedWrongCount), firstExpected, firstVariance)
+	secondScore := zScore(float64(res.SharedWrongCount), secondExpected, secondVariance)
+	if firstScore >= secondScore {
+		res.Score = firstScore
+		res.ExpectedSharedWrongCount = firstExpected
+	} else {
+		res.Score = secondScore
+		res.ExpectedSharedWrongCount = secondExpected
+	}
+	return res
+}
+
+// otherShare is the share of the participants outside the pair who picked the source's option.
+func (s *service) otherShare(chosenCount int, source *participant.ParticipantAnswers, other *participant.ParticipantAnswers, otherCount int) float64 {
+	chosenCount--
+	if other.McqOptionID == source.McqOptionID {
+		chosenCount--
+	}
+	return float64(max(chosenCount, 0)) / float64(otherCount)
+}
+
+func zScore(observed float64, expected float64, variance float64) float64 {
+	if variance == 0 {
+		return 0
+	}
+	return (observed - expected) / math.Sqrt(variance)
+}
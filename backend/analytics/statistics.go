@@ -0,0 +1,206 @@
+package analytics
+
+import (
+	"log"
+	"math"
+	"sort"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+)
+
+// histogramBinCount is the number of equal width bins between the lowest and the highest total point.
+const histogramBinCount = 10
+
+// GetExamStatisticsByExamID returns the score distribution and reliability over the submitted participants.
+// The cached result is reused as long as no participant has submitted since it was computed.
+func (s *service) GetExamStatisticsByExamID(examID uint) (*ExamStatistics, error) {
+	participants, err := s.participantService.GetParticipantsByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	submittedParticipantIDs := map[uint]bool{}
+	for _, p := range participants {
+		if p.IsSubmitted() {
+			submittedParticipantIDs[p.ID] = true
+		}
+	}
+
+	cached, err := s.analyticsRepository.GetExamStatisticsByExamID(examID)
+	if err == nil && cached.SubmittedCount == len(submittedParticipantIDs) {
+		return cached, nil
+	}
+
+	totalPoints, err := s.participantService.GetParticipantTotalPointsByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	participantsAnswers, err := s.participantService.GetParticipantsAnswersByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ExamStatistics{
+		SubmittedCount: len(submittedParticipantIDs),
+		Histogram:      []*HistogramBin{},
+	}
+
+	totals := []float64{}
+	for _, totalPoint := range totalPoints {
+		if submittedParticipantIDs[totalPoint.ParticipantID] {
+			totals = append(totals, float64(totalPoint.TotalPoint))
+		}
+	}
+	s.describeTotals(res, totals)
+	s.estimateReliability(res, submittedParticipantIDs, participantsAnswers)
+
+	err = s.analyticsRepository.SaveExamStatistics(examID, res)
+	if err != nil {
+		log.Println("[analytics][service][GetExamStatisticsByExamID] failed to cache exam statistics:", err.Error())
+	}
+	return res, nil
+}
+
+func (s *service) InvalidateExamStatistics(examID uint) {
+	err := s.analyticsRepository.DeleteExamStatisticsByExamID(examID)
+	if err != nil {
+		log.Println("[analytics][service][InvalidateExamStatistics] failed to invalidate exam statistics:", err.Error())
+	}
+}
+
+func (s *service) InvalidateExamStatisticsByParticipantID(participantID uint) {
+	p, err := s.participantService.GetParticipantByID(participantID)
+	if err != nil {
+		return
+	}
+	s.InvalidateExamStatistics(p.ExamID)
+}
+
+func (s *service) describeTotals(res *ExamStatistics, totals []float64) {
+	if len(totals) == 0 {
+		return
+	}
+	sort.Float64s(totals)
+
+	n := len(totals)
+	res.Min = int(totals[0])
+	res.Max = int(totals[n-1])
+	res.Mean, res.StandardDeviation = meanAndStandardDeviation(totals)
+	if n%2 == 1 {
+		res.Median = totals[n/2]
+	} else {
+		res.Median = (totals[n/2-1] + totals[n/2]) / 2
+	}
+
+	binCount := histogramBinCount
+	if res.Min == res.Max {
+		binCount = 1
+	}
+	binWidth := float64(res.Max-res.Min) / float64(binCount)
+	for i := 0; i < binCount; i++ {
+		res.Histogram = append(res.Histogram, &HistogramBin{
+			LowerBound: float64(res.Min) + float64(i)*binWidth,
+			UpperBound: float64(res.Min) + float64(i+1)*binWidth,
+		})
+	}
+	for _, total := range totals {
+		i := binCount - 1
+		if binWidth > 0 {
+			i = min(int((total-float64(res.Min))/binWidth), binCount-1)
+		}
+		res.Histogram[i].Count++
+	}
+}
+
+// estimateReliability computes KR-20 on the correctness and Cronbach's alpha on the points of the questions served to every
+// submitted participant, since the reliability is not defined for questions that only some participants drew.
+func (s *service) estimateReliability(res *ExamStatistics, submittedParticipantIDs map[uint]bool, participantsAnswers []*participant.ParticipantAnswers) {
+	mapParticipantAnswers := map[uint]map[uint]*participant.ParticipantAnswers{}
+	servedCounts := map[uint]int{}
+	questionIDs := []uint{}
+	for _, participantAnswer := range participantsAnswers {
+		if !submittedParticipantIDs[participantAnswer.ParticipantID] {
+			continue
+		}
+		if _, ok := mapParticipantAnswers[participantAnswer.ParticipantID]; !ok {
+			mapParticipantAnswers[participantAnswer.ParticipantID] = map[uint]*participant.ParticipantAnswers{}
+		}
+		mapParticipantAnswers[participantAnswer.ParticipantID][participantAnswer.QuestionID] = participantAnswer
+		if servedCounts[participantAnswer.QuestionID] == 0 {
+			questionIDs = append(questionIDs, participantAnswer.QuestionID)
+		}
+		servedCounts[participantAnswer.QuestionID]++
+	}
+
+	commonQuestionIDs := []uint{}
+	for _, questionID := range questionIDs {
+		if servedCounts[questionID] == len(mapParticipantAnswers) {
+			commonQuestionIDs = append(commonQuestionIDs, questionID)
+		}
+	}
+	res.ItemCount = len(commonQuestionIDs)
+	if res.ItemCount < 2 || len(mapParticipantAnswers) < 2 {
+		return
+	}
+
+	correctTotals := []float64{}
+	pointTotals := []float64{}
+	for _, answers := range mapParticipantAnswers {
+		correctTotal, pointTotal := 0.0, 0.0
+		for _, questionID := range commonQuestionIDs {
+			if answers[questionID].IsCorrect {
+				correctTotal++
+			}
+			pointTotal += float64(answers[questionID].Point)
+		}
+		correctTotals = append(correctTotals, correctTotal)
+		pointTotals = append(pointTotals, pointTotal)
+	}
+
+	sumCorrectVariance, sumPointVariance := 0.0, 0.0
+	for _, questionID := range commonQuestionIDs {
+		correct, points := []float64{}, []float64{}
+		for _, answers := range mapParticipantAnswers {
+			if answers[questionID].IsCorrect {
+				correct = append(correct, 1)
+			} else {
+				correct = append(correct, 0)
+			}
+			points = append(points, float64(answers[questionID].Point))
+		}
+		_, correctStandardDeviation := meanAndStandardDeviation(correct)
+		_, pointStandardDeviation := meanAndStandardDeviation(points)
+		sumCorrectVariance += correctStandardDeviation * correctStandardDeviation
+		sumPointVariance += pointStandardDeviation * pointStandardDeviation
+	}
+
+	k := float64(res.ItemCount)
+	_, correctTotalStandardDeviation := meanAndStandardDeviation(correctTotals)
+	if correctTotalStandardDeviation > 0 {
+		res.KR20 = k / (k - 1) * (1 - sumCorrectVariance/(correctTotalStandardDeviation*correctTotalStandardDeviation))
+	}
+	_, pointTotalStandardDeviation := meanAndStandardDeviation(pointTotals)
+	if pointTotalStandardDeviation > 0 {
+		res.CronbachAlpha = k / (k - 1) * (1 - sumPointVariance/(pointTotalStandardDeviation*pointTotalStandardDeviation))
+	}
+
+	reliability := math.Max(0, math.Min(1, res.CronbachAlpha))
+	res.StandardErrorOfMeasurement = res.StandardDeviation * math.Sqrt(1-reliability)
+}
+
+// meanAndStandardDeviation returns the mean and the population standard deviation.
+func meanAndStandardDeviation(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+
+	sumSquares := 0.0
+	for _, value := range values {
+		sumSquares += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(sumSquares / float64(len(values)))
+}
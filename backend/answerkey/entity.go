@@ -0,0 +1,34 @@
+package answerkey
+
+import (
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+type AnswerKeyRevision struct {
+	lib.BaseModel
+	ExamID     uint
+	QuestionID uint
+	Reason     string
+}
+
+type AnswerKeyRevisionOption struct {
+	lib.BaseModel
+	AnswerKeyRevisionID uint
+	McqOptionID         uint
+	PreviousPoint       int
+	NewPoint            int
+}
+
+type AnswerKeyRevisionScore struct {
+	lib.BaseModel
+	AnswerKeyRevisionID uint
+	ParticipantID       uint
+	PreviousTotalPoint  int
+	NewTotalPoint       int
+}
+
+type AnswerKeyRevisionDetail struct {
+	Revision *AnswerKeyRevision
+	Options  []*AnswerKeyRevisionOption
+	Scores   []*AnswerKeyRevisionScore
+}
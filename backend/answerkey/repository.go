@@ -0,0 +1,179 @@
+package answerkey
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/mcqoption"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	redis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateAnswerKeyRevision(revision *AnswerKeyRevision, options []*AnswerKeyRevisionOption) (*AnswerKeyRevisionDetail, error)
+	GetAnswerKeyRevisionByID(id uint) (*AnswerKeyRevision, error)
+	GetAnswerKeyRevisionsByExamID(examID uint) ([]*AnswerKeyRevision, error)
+	GetAnswerKeyRevisionOptionsByRevisionID(revisionID uint) ([]*AnswerKeyRevisionOption, error)
+	GetAnswerKeyRevisionScoresByRevisionID(revisionID uint) ([]*AnswerKeyRevisionScore, error)
+}
+
+type repository struct {
+	cfg                   *config.Config
+	db                    *gorm.DB
+	cache                 *redis.Client
+	mcqOptionRepository   mcqoption.Repository
+	participantRepository participant.Repository
+}
+
+func NewRepository(
+	cfg *config.Config,
+	db *gorm.DB,
+	cache *redis.Client,
+	mcqOptionRepository mcqoption.Repository,
+	participantRepository participant.Repository,
+) Repository {
+	return &repository{
+		cfg:                   cfg,
+		db:                    db,
+		cache:                 cache,
+		mcqOptionRepository:   mcqOptionRepository,
+		participantRepository: participantRepository,
+	}
+}
+
+// CreateAnswerKeyRevision applies the new points to the question's mcq options and records the previous ones, along with the total point of every affected participant before and after the change.
+// Options whose point does not change are left out of the revision.
+// The exam row is locked for the whole transaction, so the totals of concurrent revisions of the same exam do not overlap, and both totals are read from the same snapshot, so answers saved meanwhile do not count as a score change.
+func (r *repository) CreateAnswerKeyRevision(revision *AnswerKeyRevision, options []*AnswerKeyRevisionOption) (*AnswerKeyRevisionDetail, error) {
+	changedOptions := []*AnswerKeyRevisionOption{}
+	scores := []*AnswerKeyRevisionScore{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var examIDs []uint
+		err := tx.Table("exams").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", revision.ExamID).
+			Pluck("id", &examIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(examIDs) == 0 {
+			return lib.ErrExamNotFound
+		}
+
+		previousTotalPoints, err := r.participantRepository.GetParticipantTotalPointsByExamIDInTx(tx, revision.ExamID)
+		if err != nil {
+			return err
+		}
+
+		var mcqOptions []*mcqoption.McqOption
+		if err := tx.Where("question_id = ?", revision.QuestionID).Find(&mcqOptions).Error; err != nil {
+			return err
+		}
+		currentPoints := map[uint]int{}
+		for _, mcqOption := range mcqOptions {
+			currentPoints[mcqOption.ID] = mcqOption.Point
+		}
+
+		for _, option := range options {
+			previousPoint, ok := currentPoints[option.McqOptionID]
+			if !ok {
+				return lib.ErrMcqOptionNotFound
+			}
+			if previousPoint == option.NewPoint {
+				continue
+			}
+			option.PreviousPoint = previousPoint
+			changedOptions = append(changedOptions, option)
+		}
+		if len(changedOptions) == 0 {
+			return lib.ErrAnswerKeyNotChanged
+		}
+
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		for _, option := range changedOptions {
+			option.AnswerKeyRevisionID = revision.ID
+			if err := tx.Model(&mcqoption.McqOption{}).Where("id = ?", option.McqOptionID).Update("point", option.NewPoint).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.CreateInBatches(changedOptions, constants.InsertionBatchSize).Error; err != nil {
+			return err
+		}
+
+		newTotalPoints, err := r.participantRepository.GetParticipantTotalPointsByExamIDInTx(tx, revision.ExamID)
+		if err != nil {
+			return err
+		}
+
+		mapPreviousTotalPoints := map[uint]int{}
+		for _, totalPoint := range previousTotalPoints {
+			mapPreviousTotalPoints[totalPoint.ParticipantID] = totalPoint.TotalPoint
+		}
+		for _, totalPoint := range newTotalPoints {
+			previousTotalPoint := mapPreviousTotalPoints[totalPoint.ParticipantID]
+			if previousTotalPoint == totalPoint.TotalPoint {
+				continue
+			}
+			scores = append(scores, &AnswerKeyRevisionScore{
+				AnswerKeyRevisionID: revision.ID,
+				ParticipantID:       totalPoint.ParticipantID,
+				PreviousTotalPoint:  previousTotalPoint,
+				NewTotalPoint:       totalPoint.TotalPoint,
+			})
+		}
+		if len(scores) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(scores, constants.InsertionBatchSize).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, option := range changedOptions {
+		r.cache.Del(context.Background(), r.mcqOptionRepository.GetMcqOptionByIDCacheKey(option.McqOptionID))
+	}
+	r.cache.Del(context.Background(), r.mcqOptionRepository.GetMcqOptionByQuestionIDCacheKey(revision.QuestionID))
+	return &AnswerKeyRevisionDetail{
+		Revision: revision,
+		Options:  changedOptions,
+		Scores:   scores,
+	}, nil
+}
+
+func (r *repository) GetAnswerKeyRevisionByID(id uint) (*AnswerKeyRevision, error) {
+	var revision AnswerKeyRevision
+	err := r.db.Where("id = ?", id).First(&revision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, lib.ErrAnswerKeyRevisionNotFound
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *repository) GetAnswerKeyRevisionsByExamID(examID uint) ([]*AnswerKeyRevision, error) {
+	var res []*AnswerKeyRevision
+	err := r.db.Where("exam_id = ?", examID).Order("id DESC").Find(&res).Error
+	return res, err
+}
+
+func (r *repository) GetAnswerKeyRevisionOptionsByRevisionID(revisionID uint) ([]*AnswerKeyRevisionOption, error) {
+	var res []*AnswerKeyRevisionOption
+	err := r.db.Where("answer_key_revision_id = ?", revisionID).Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (r *repository) GetAnswerKeyRevisionScoresByRevisionID(revisionID uint) ([]*AnswerKeyRevisionScore, error) {
+	var res []*AnswerKeyRevisionScore
+	err := r.db.Where("answer_key_revision_id = ?", revisionID).Order("participant_id ASC").Find(&res).Error
+	return res, err
+}
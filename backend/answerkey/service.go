@@ -0,0 +1,77 @@
+package answerkey
+
+import (
+	"errors"
+	"log"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+type Service interface {
+	ReviseAnswerKey(revision *AnswerKeyRevision, options []*AnswerKeyRevisionOption) (*AnswerKeyRevisionDetail, error)
+	GetAnswerKeyRevisionsByExamID(examID uint) ([]*AnswerKeyRevision, error)
+	GetAnswerKeyRevisionDetailByID(id uint) (*AnswerKeyRevisionDetail, error)
+}
+
+type service struct {
+	answerKeyRepository Repository
+}
+
+func NewService(
+	answerKeyRepository Repository,
+) Service {
+	return &service{
+		answerKeyRepository: answerKeyRepository,
+	}
+}
+
+// ReviseAnswerKey changes the points of a question's options and stores the total point of every affected participant before and after the change.
+func (s *service) ReviseAnswerKey(revision *AnswerKeyRevision, options []*AnswerKeyRevisionOption) (*AnswerKeyRevisionDetail, error) {
+	res, err := s.answerKeyRepository.CreateAnswerKeyRevision(revision, options)
+	if err != nil {
+		log.Println("[answerkey][service][ReviseAnswerKey] failed to create answer key revision:", err.Error())
+		if errors.Is(err, lib.ErrMcqOptionNotFound) || errors.Is(err, lib.ErrAnswerKeyNotChanged) {
+			return nil, err
+		}
+		return nil, lib.ErrFailedToReviseAnswerKey
+	}
+	return res, nil
+}
+
+func (s *service) GetAnswerKeyRevisionsByExamID(examID uint) ([]*AnswerKeyRevision, error) {
+	res, err := s.answerKeyRepository.GetAnswerKeyRevisionsByExamID(examID)
+	if err != nil {
+		log.Println("[answerkey][service][GetAnswerKeyRevisionsByExamID] failed to get answer key revisions:", err.Error())
+		return nil, lib.ErrFailedToGetAnswerKeyRevisions
+	}
+	return res, nil
+}
+
+func (s *service) GetAnswerKeyRevisionDetailByID(id uint) (*AnswerKeyRevisionDetail, error) {
+	revision, err := s.answerKeyRepository.GetAnswerKeyRevisionByID(id)
+	if err != nil {
+		log.Println("[answerkey][service][GetAnswerKeyRevisionDetailByID] failed to get answer key revision:", err.Error())
+		if errors.Is(err, lib.ErrAnswerKeyRevisionNotFound) {
+			return nil, err
+		}
+		return nil, lib.ErrFailedToGetAnswerKeyRevision
+	}
+
+	options, err := s.answerKeyRepository.GetAnswerKeyRevisionOptionsByRevisionID(id)
+	if err != nil {
+		log.Println("[answerkey][service][GetAnswerKeyRevisionDetailByID] failed to get answer key revision options:", err.Error())
+		return nil, lib.ErrFailedToGetAnswerKeyRevision
+	}
+
+	scores, err := s.answerKeyRepository.GetAnswerKeyRevisionScoresByRevisionID(id)
+	if err != nil {
+		log.Println("[answerkey][service][GetAnswerKeyRevisionDetailByID] failed to get answer key revision scores:", err.Error())
+		return nil, lib.ErrFailedToGetAnswerKeyRevision
+	}
+
+	return &AnswerKeyRevisionDetail{
+		Revision: revision,
+		Options:  options,
+		Scores:   scores,
+	}, nil
+}
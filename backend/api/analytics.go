@@ -0,0 +1,349 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/analytics"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+)
+
+/***
+	entity
+***/
+
+type ItemAnalysisData struct {
+	QuestionID          uint                  `json:"question_id"`
+	OrderNumber         int                   `json:"order_number"`
+	ServedCount         int                   `json:"served_count"`
+	CorrectCount        int                   `json:"correct_count"`
+	PValue              float64               `json:"p_value"`
+	DiscriminationIndex float64               `json:"discrimination_index"`
+	PointBiserial       float64               `json:"point_biserial"`
+	BlankCount          int                   `json:"blank_count"`
+	BlankShare          float64               `json:"blank_share"`
+	Options             []*OptionAnalysisData `json:"options"`
+}
+
+type OptionAnalysisData struct {
+	McqOptionID uint    `json:"mcq_option_id"`
+	Description string  `json:"description"`
+	Point       int     `json:"point"`
+	IsKey       bool    `json:"is_key"`
+	ChosenCount int     `json:"chosen_count"`
+	ChosenShare float64 `json:"chosen_share"`
+}
+
+type ExamStatisticsData struct {
+	SubmittedCount             int                 `json:"submitted_count"`
+	Mean                       float64             `json:"mean"`
+	Median                     float64             `json:"median"`
+	StandardDeviation          float64             `json:"standard_deviation"`
+	Min                        int                 `json:"min"`
+	Max                        int                 `json:"max"`
+	Histogram                  []*HistogramBinData `json:"histogram"`
+	ItemCount                  int                 `json:"item_count"`
+	KR20                       float64             `json:"kr20"`
+	CronbachAlpha              float64             `json:"cronbach_alpha"`
+	StandardErrorOfMeasurement float64             `json:"standard_error_of_measurement"`
+}
+
+type HistogramBinData struct {
+	LowerBound float64 `json:"lower_bound"`
+	UpperBound float64 `json:"upper_bound"`
+	Count      int     `json:"count"`
+}
+
+type AnswerSimilarityPairData struct {
+	FirstParticipantID       uint      `json:"first_participant_id"`
+	FirstParticipantName     string    `json:"first_participant_name"`
+	SecondParticipantID      uint      `json:"second_participant_id"`
+	SecondParticipantName    string    `json:"second_participant_name"`
+	CommonQuestionCount      int       `json:"common_question_count"`
+	IdenticalAnswerCount     int       `json:"identical_answer_count"`
+	SharedWrongCount         int       `json:"shared_wrong_count"`
+	ExpectedSharedWrongCount float64   `json:"expected_shared_wrong_count"`
+	Score                    float64   `json:"score"`
+	DetectedAt               time.Time `json:"detected_at"`
+}
+
+/***
+	handler
+***/
+
+func (h *handler) GetItemAnalysis(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if !h.ensureExamReconciled(c, exam.ID) {
+		return
+	}
+
+	svcRes, err := h.analyticsService.GetItemAnalysisByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if c.Query(constants.QueryParameterFormat) != constants.FormatCSV {
+		res := h.MapItemAnalysisEntityListToItemAnalysisDataList(svcRes)
+		c.JSON(http.StatusOK, lib.BaseResponse{
+			Message: constants.Success,
+			Data:    res,
+		})
+		return
+	}
+
+	// one row per option of each question, followed by a row for the blank answers
+	res := [][]string{{"nomor_soal", "id_soal", "jumlah_peserta", "p_value", "daya_beda", "point_biserial", "id_opsi", "opsi", "kunci", "jumlah_pemilih", "proporsi_pemilih"}}
+	for _, item := range svcRes {
+		itemColumns := []string{
+			fmt.Sprintf("%d", item.OrderNumber),
+			fmt.Sprintf("%d", item.QuestionID),
+			fmt.Sprintf("%d", item.ServedCount),
+			fmt.Sprintf("%.4f", item.PValue),
+			fmt.Sprintf("%.4f", item.DiscriminationIndex),
+			fmt.Sprintf("%.4f", item.PointBiserial),
+		}
+		for _, option := range item.Options {
+			isKey := "tidak"
+			if option.IsKey {
+				isKey = "ya"
+			}
+			row := append([]string{}, itemColumns...)
+			row = append(row, fmt.Sprintf("%d", option.McqOptionID), option.Description, isKey, fmt.Sprintf("%d", option.ChosenCount), fmt.Sprintf("%.4f", option.ChosenShare))
+			res = append(res, row)
+		}
+		row := append([]string{}, itemColumns...)
+		row = append(row, "-", "(kosong)", "tidak", fmt.Sprintf("%d", item.BlankCount), fmt.Sprintf("%.4f", item.BlankShare))
+		res = append(res, row)
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=analisis_butir_%s_%s.csv", exam.Name, exam.Serial))
+	c.Header("Content-Type", "text/csv")
+
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.WriteAll(res); err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	writer.Flush()
+}
+
+func (h *handler) GetExamStatistics(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if !h.ensureExamReconciled(c, exam.ID) {
+		return
+	}
+
+	svcRes, err := h.analyticsService.GetExamStatisticsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapExamStatisticsEntityToExamStatisticsData(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) RequestAnswerSimilarityDetection(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if !h.ensureExamReconciled(c, exam.ID) {
+		return
+	}
+
+	err = h.analyticsService.RequestAnswerSimilarityDetection(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusAccepted, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+func (h *handler) GetAnswerSimilarityPairs(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.analyticsService.GetAnswerSimilarityPairsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	participants, err := h.participantService.GetParticipantsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapAnswerSimilarityPairEntityListToAnswerSimilarityPairDataList(svcRes, participants)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+// InvalidateExamStatisticsByQuestionID drops the cached statistics of the exam the question belongs to.
+func (h *handler) InvalidateExamStatisticsByQuestionID(questionID uint) {
+	question, err := h.questionService.GetQuestionByID(questionID)
+	if err != nil {
+		return
+	}
+	h.analyticsService.InvalidateExamStatistics(question.ExamID)
+}
+
+/***
+	mapping
+***/
+
+func (h *handler) MapItemAnalysisEntityToItemAnalysisData(svcRes *analytics.ItemAnalysis) *ItemAnalysisData {
+	res := &ItemAnalysisData{
+		QuestionID:          svcRes.QuestionID,
+		OrderNumber:         svcRes.OrderNumber,
+		ServedCount:         svcRes.ServedCount,
+		CorrectCount:        svcRes.CorrectCount,
+		PValue:              svcRes.PValue,
+		DiscriminationIndex: svcRes.DiscriminationIndex,
+		PointBiserial:       svcRes.PointBiserial,
+		BlankCount:          svcRes.BlankCount,
+		BlankShare:          svcRes.BlankShare,
+		Options:             []*OptionAnalysisData{},
+	}
+	for _, option := range svcRes.Options {
+		res.Options = append(res.Options, &OptionAnalysisData{
+			McqOptionID: option.McqOptionID,
+			Description: option.Description,
+			Point:       option.Point,
+			IsKey:       option.IsKey,
+			ChosenCount: option.ChosenCount,
+			ChosenShare: option.ChosenShare,
+		})
+	}
+	return res
+}
+
+func (h *handler) MapItemAnalysisEntityListToItemAnalysisDataList(svcRes []*analytics.ItemAnalysis) []*ItemAnalysisData {
+	res := []*ItemAnalysisData{}
+	for _, obj := range svcRes {
+		res = append(res, h.MapItemAnalysisEntityToItemAnalysisData(obj))
+	}
+	return res
+}
+
+func (h *handler) MapExamStatisticsEntityToExamStatisticsData(svcRes *analytics.ExamStatistics) *ExamStatisticsData {
+	res := &ExamStatisticsData{
+		SubmittedCount:             svcRes.SubmittedCount,
+		Mean:                       svcRes.Mean,
+		Median:                     svcRes.Median,
+		StandardDeviation:          svcRes.StandardDeviation,
+		Min:                        svcRes.Min,
+		Max:                        svcRes.Max,
+		Histogram:                  []*HistogramBinData{},
+		ItemCount:                  svcRes.ItemCount,
+		KR20:                       svcRes.KR20,
+		CronbachAlpha:              svcRes.CronbachAlpha,
+		StandardErrorOfMeasurement: svcRes.StandardErrorOfMeasurement,
+	}
+	for _, bin := range svcRes.Histogram {
+		res.Histogram = append(res.Histogram, &HistogramBinData{
+			LowerBound: bin.LowerBound,
+			UpperBound: bin.UpperBound,
+			Count:      bin.Count,
+		})
+	}
+	return res
+}
+
+func (h *handler) MapAnswerSimilarityPairEntityListToAnswerSimilarityPairDataList(svcRes []*analytics.AnswerSimilarityPair, participants []*participant.Participant) []*AnswerSimilarityPairData {
+	participantNames := map[uint]string{}
+	for _, p := range participants {
+		participantNames[p.ID] = p.Name
+	}
+
+	res := []*AnswerSimilarityPairData{}
+	for _, obj := range svcRes {
+		res = append(res, &AnswerSimilarityPairData{
+			FirstParticipantID:       obj.FirstParticipantID,
+			FirstParticipantName:     participantNames[obj.FirstParticipantID],
+			SecondParticipantID:      obj.SecondParticipantID,
+			SecondParticipantName:    participantNames[obj.SecondParticipantID],
+			CommonQuestionCount:      obj.CommonQuestionCount,
+			IdenticalAnswerCount:     obj.IdenticalAnswerCount,
+			SharedWrongCount:         obj.SharedWrongCount,
+			ExpectedSharedWrongCount: obj.ExpectedSharedWrongCount,
+			Score:                    obj.Score,
+			DetectedAt:               obj.CreatedAt,
+		})
+	}
+	return res
+}
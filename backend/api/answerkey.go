@@ -0,0 +1,295 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/answerkey"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+/***
+	entity
+***/
+
+type ReviseAnswerKeyRequest struct {
+	QuestionID uint                            `json:"question_id" binding:"required"`
+	ExamID     uint                            `json:"-"`
+	Reason     string                          `json:"reason"`
+	Options    []*ReviseAnswerKeyOptionRequest `json:"options" binding:"required,dive,required"`
+}
+
+type ReviseAnswerKeyOptionRequest struct {
+	McqOptionID uint `json:"mcq_option_id" binding:"required"`
+	Point       int  `json:"point"`
+}
+
+type AnswerKeyRevisionData struct {
+	ID         uint      `json:"id"`
+	QuestionID uint      `json:"question_id"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AnswerKeyRevisionOptionData struct {
+	McqOptionID   uint `json:"mcq_option_id"`
+	PreviousPoint int  `json:"previous_point"`
+	NewPoint      int  `json:"new_point"`
+}
+
+type AnswerKeyRevisionScoreData struct {
+	ParticipantID      uint   `json:"participant_id"`
+	ParticipantName    string `json:"participant_name"`
+	PreviousTotalPoint int    `json:"previous_total_point"`
+	NewTotalPoint      int    `json:"new_total_point"`
+	Difference         int    `json:"difference"`
+}
+
+type AnswerKeyRevisionDetailData struct {
+	Revision *AnswerKeyRevisionData         `json:"revision"`
+	Options  []*AnswerKeyRevisionOptionData `json:"options"`
+	Scores   []*AnswerKeyRevisionScoreData  `json:"scores"`
+}
+
+/***
+	handler
+***/
+
+func (h *handler) ReviseAnswerKey(c *gin.Context) {
+	var req ReviseAnswerKeyRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	question, err := h.questionService.GetQuestionByID(req.QuestionID)
+	if err != nil {
+		if errors.Is(err, lib.ErrQuestionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	req.ExamID = question.ExamID
+	revision, options := h.MapReviseAnswerKeyRequestToAnswerKeyRevisionEntity(&req)
+
+	svcRes, err := h.answerKeyService.ReviseAnswerKey(revision, options)
+	if err != nil {
+		if errors.Is(err, lib.ErrMcqOptionNotFound) || errors.Is(err, lib.ErrAnswerKeyNotChanged) {
+			c.JSON(http.StatusBadRequest, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.analyticsService.InvalidateExamStatistics(question.ExamID)
+
+	res, err := h.MapAnswerKeyRevisionDetailEntityToAnswerKeyRevisionDetailData(svcRes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) GetAnswerKeyRevisionsByExamSerial(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.answerKeyService.GetAnswerKeyRevisionsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapAnswerKeyRevisionEntityListToAnswerKeyRevisionDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) GetAnswerKeyRevisionByID(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	svcRes, err := h.answerKeyService.GetAnswerKeyRevisionDetailByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrAnswerKeyRevisionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res, err := h.MapAnswerKeyRevisionDetailEntityToAnswerKeyRevisionDetailData(svcRes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) DownloadAnswerKeyRevision(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	svcRes, err := h.answerKeyService.GetAnswerKeyRevisionDetailByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrAnswerKeyRevisionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	detail, err := h.MapAnswerKeyRevisionDetailEntityToAnswerKeyRevisionDetailData(svcRes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := [][]string{{"kode_peserta", "total_poin_sebelum", "total_poin_sesudah", "selisih"}}
+	for _, score := range detail.Scores {
+		res = append(res, []string{
+			score.ParticipantName,
+			fmt.Sprintf("%d", score.PreviousTotalPoint),
+			fmt.Sprintf("%d", score.NewTotalPoint),
+			fmt.Sprintf("%d", score.Difference),
+		})
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=revisi_kunci_%d.csv", detail.Revision.ID))
+	c.Header("Content-Type", "text/csv")
+
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.WriteAll(res); err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	writer.Flush()
+}
+
+/***
+	mapping
+***/
+
+func (h *handler) MapReviseAnswerKeyRequestToAnswerKeyRevisionEntity(req *ReviseAnswerKeyRequest) (*answerkey.AnswerKeyRevision, []*answerkey.AnswerKeyRevisionOption) {
+	options := []*answerkey.AnswerKeyRevisionOption{}
+	for _, option := range req.Options {
+		options = append(options, &answerkey.AnswerKeyRevisionOption{
+			McqOptionID: option.McqOptionID,
+			NewPoint:    option.Point,
+		})
+	}
+	return &answerkey.AnswerKeyRevision{
+		ExamID:     req.ExamID,
+		QuestionID: req.QuestionID,
+		Reason:     req.Reason,
+	}, options
+}
+
+func (h *handler) MapAnswerKeyRevisionEntityToAnswerKeyRevisionData(svcRes *answerkey.AnswerKeyRevision) *AnswerKeyRevisionData {
+	return &AnswerKeyRevisionData{
+		ID:         svcRes.ID,
+		QuestionID: svcRes.QuestionID,
+		Reason:     svcRes.Reason,
+		CreatedAt:  svcRes.CreatedAt,
+	}
+}
+
+func (h *handler) MapAnswerKeyRevisionEntityListToAnswerKeyRevisionDataList(svcRes []*answerkey.AnswerKeyRevision) []*AnswerKeyRevisionData {
+	res := []*AnswerKeyRevisionData{}
+	for _, obj := range svcRes {
+		res = append(res, h.MapAnswerKeyRevisionEntityToAnswerKeyRevisionData(obj))
+	}
+	return res
+}
+
+func (h *handler) MapAnswerKeyRevisionDetailEntityToAnswerKeyRevisionDetailData(svcRes *answerkey.AnswerKeyRevisionDetail) (*AnswerKeyRevisionDetailData, error) {
+	participants, err := h.participantService.GetParticipantsByExamID(svcRes.Revision.ExamID)
+	if err != nil {
+		return nil, err
+	}
+	participantNames := map[uint]string{}
+	for _, p := range participants {
+		participantNames[p.ID] = p.Name
+	}
+
+	res := &AnswerKeyRevisionDetailData{
+		Revision: h.MapAnswerKeyRevisionEntityToAnswerKeyRevisionData(svcRes.Revision),
+		Options:  []*AnswerKeyRevisionOptionData{},
+		Scores:   []*AnswerKeyRevisionScoreData{},
+	}
+	for _, option := range svcRes.Options {
+		res.Options = append(res.Options, &AnswerKeyRevisionOptionData{
+			McqOptionID:   option.McqOptionID,
+			PreviousPoint: option.PreviousPoint,
+			NewPoint:      option.NewPoint,
+		})
+	}
+	for _, score := range svcRes.Scores {
+		res.Scores = append(res.Scores, &AnswerKeyRevisionScoreData{
+			ParticipantID:      score.ParticipantID,
+			ParticipantName:    participantNames[score.ParticipantID],
+			PreviousTotalPoint: score.PreviousTotalPoint,
+			NewTotalPoint:      score.NewTotalPoint,
+			Difference:         score.NewTotalPoint - score.PreviousTotalPoint,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/deadletter"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+/***
+	entity
+***/
+
+type DeadLetterData struct {
+	ID         uint       `json:"id"`
+	QueueName  string     `json:"queue_name"`
+	Payload    string     `json:"payload"`
+	Reason     string     `json:"reason"`
+	Attempts   int        `json:"attempts"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReplayedAt *time.Time `json:"replayed_at"`
+}
+
+/***
+	handler
+***/
+
+func (h *handler) GetDeadLetters(c *gin.Context) {
+	var filter deadletter.GetDeadLettersFilter
+
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	pagination, err := lib.GetQueryPaginationFromContext(c)
+	if err != nil {
+		log.Printf("[handler][deadletter][GetDeadLetters] get query pagination error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.deadLetterService.GetDeadLetters(pagination, &filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapDeadLetterEntityListToDeadLetterDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) ReplayDeadLetter(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	err := h.deadLetterService.ReplayDeadLetterByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrDeadLetterNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, lib.ErrDeadLetterAlreadyReplayed) {
+			c.JSON(http.StatusConflict, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+/***
+	mapping
+***/
+
+func (h *handler) MapDeadLetterEntityToDeadLetterData(svcRes *deadletter.DeadLetter) *DeadLetterData {
+	return &DeadLetterData{
+		ID:         svcRes.ID,
+		QueueName:  svcRes.QueueName,
+		Payload:    svcRes.Payload,
+		Reason:     svcRes.Reason,
+		Attempts:   svcRes.Attempts,
+		CreatedAt:  svcRes.CreatedAt,
+		ReplayedAt: svcRes.ReplayedAt,
+	}
+}
+
+func (h *handler) MapDeadLetterEntityListToDeadLetterDataList(svcRes []*deadletter.DeadLetter) []*DeadLetterData {
+	res := []*DeadLetterData{}
+	for _, obj := range svcRes {
+		res = append(res, h.MapDeadLetterEntityToDeadLetterData(obj))
+	}
+	return res
+}
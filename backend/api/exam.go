@@ -30,23 +30,44 @@ import (
 ***/
 
 type CreateExamRequest struct {
-	Name                   string `json:"name" binding:"required"`
-	IsOpen                 bool   `json:"is_open"`
-	AllowedDurationMinutes uint   `json:"allowed_duration_minutes"`
+	Name                   string   `json:"name" binding:"required"`
+	IsOpen                 bool     `json:"is_open"`
+	AllowedDurationMinutes uint     `json:"allowed_duration_minutes"`
+	WrongAnswerPenalty     int      `json:"wrong_answer_penalty"`
+	BlankAnswerPoint       int      `json:"blank_answer_point"`
+	MinQuestionPoint       *int     `json:"min_question_point"`
+	MinExamPoint           *int     `json:"min_exam_point"`
+	MaxExamPoint           *int     `json:"max_exam_point"`
+	MaxScore               *int     `json:"max_score"`
+	PassingScore           *float64 `json:"passing_score"`
 }
 
 type ExamData struct {
-	Serial                 string `json:"serial"`
-	Name                   string `json:"name"`
-	IsOpen                 bool   `json:"is_open"`
-	AllowedDurationMinutes uint   `json:"allowed_duration_minutes"`
+	Serial                 string   `json:"serial"`
+	Name                   string   `json:"name"`
+	IsOpen                 bool     `json:"is_open"`
+	AllowedDurationMinutes uint     `json:"allowed_duration_minutes"`
+	WrongAnswerPenalty     int      `json:"wrong_answer_penalty"`
+	BlankAnswerPoint       int      `json:"blank_answer_point"`
+	MinQuestionPoint       *int     `json:"min_question_point"`
+	MinExamPoint           *int     `json:"min_exam_point"`
+	MaxExamPoint           *int     `json:"max_exam_point"`
+	MaxScore               *int     `json:"max_score"`
+	PassingScore           *float64 `json:"passing_score"`
 }
 
 type UpdateExamRequest struct {
-	Serial                 string `json:"-"`
-	Name                   string `json:"name" binding:"required"`
-	IsOpen                 bool   `json:"is_open"`
-	AllowedDurationMinutes uint   `json:"allowed_duration_minutes"`
+	Serial                 string   `json:"-"`
+	Name                   string   `json:"name" binding:"required"`
+	IsOpen                 bool     `json:"is_open"`
+	AllowedDurationMinutes uint     `json:"allowed_duration_minutes"`
+	WrongAnswerPenalty     int      `json:"wrong_answer_penalty"`
+	BlankAnswerPoint       int      `json:"blank_answer_point"`
+	MinQuestionPoint       *int     `json:"min_question_point"`
+	MinExamPoint           *int     `json:"min_exam_point"`
+	MaxExamPoint           *int     `json:"max_exam_point"`
+	MaxScore               *int     `json:"max_score"`
+	PassingScore           *float64 `json:"passing_score"`
 }
 
 /***
@@ -156,6 +177,9 @@ func (h *handler) UpdateExam(c *gin.Context) {
 		})
 		return
 	}
+	if updatedExam, err := h.examService.GetExamBySerial(req.Serial); err == nil {
+		h.analyticsService.InvalidateExamStatistics(updatedExam.ID)
+	}
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
 	})
@@ -576,6 +600,13 @@ func (h *handler) MapCreateExamRequestToExamEntity(req *CreateExamRequest) *exam
 		Name:                   req.Name,
 		IsOpen:                 req.IsOpen,
 		AllowedDurationMinutes: req.AllowedDurationMinutes,
+		WrongAnswerPenalty:     req.WrongAnswerPenalty,
+		BlankAnswerPoint:       req.BlankAnswerPoint,
+		MinQuestionPoint:       req.MinQuestionPoint,
+		MinExamPoint:           req.MinExamPoint,
+		MaxExamPoint:           req.MaxExamPoint,
+		MaxScore:               req.MaxScore,
+		PassingScore:           req.PassingScore,
 	}
 }
 
@@ -585,6 +616,13 @@ func (h *handler) MapExamEntityToExamData(svcRes *exam.Exam) *ExamData {
 		Name:                   svcRes.Name,
 		IsOpen:                 svcRes.IsOpen,
 		AllowedDurationMinutes: svcRes.AllowedDurationMinutes,
+		WrongAnswerPenalty:     svcRes.WrongAnswerPenalty,
+		BlankAnswerPoint:       svcRes.BlankAnswerPoint,
+		MinQuestionPoint:       svcRes.MinQuestionPoint,
+		MinExamPoint:           svcRes.MinExamPoint,
+		MaxExamPoint:           svcRes.MaxExamPoint,
+		MaxScore:               svcRes.MaxScore,
+		PassingScore:           svcRes.PassingScore,
 	}
 }
 
@@ -602,5 +640,12 @@ func (h *handler) MapUpdateExamRequestToExamEntity(req *UpdateExamRequest) *exam
 		Name:                   req.Name,
 		IsOpen:                 req.IsOpen,
 		AllowedDurationMinutes: req.AllowedDurationMinutes,
+		WrongAnswerPenalty:     req.WrongAnswerPenalty,
+		BlankAnswerPoint:       req.BlankAnswerPoint,
+		MinQuestionPoint:       req.MinQuestionPoint,
+		MinExamPoint:           req.MinExamPoint,
+		MaxExamPoint:           req.MaxExamPoint,
+		MaxScore:               req.MaxScore,
+		PassingScore:           req.PassingScore,
 	}
 }
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/grading"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+/***
+	entity
+***/
+
+type CreateGradeBandRequest struct {
+	ExamSerial string  `json:"exam_serial" binding:"required"`
+	ExamID     uint    `json:"-"`
+	Label      string  `json:"label" binding:"required"`
+	MinScore   float64 `json:"min_score"`
+}
+
+type GradeBandData struct {
+	ID       uint    `json:"id"`
+	Label    string  `json:"label"`
+	MinScore float64 `json:"min_score"`
+}
+
+/***
+	handler
+***/
+
+func (h *handler) CreateGradeBand(c *gin.Context) {
+	var req CreateGradeBandRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	if req.MinScore < 0 || req.MinScore > 100 {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	exam, err := h.examService.GetExamBySerial(req.ExamSerial)
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	req.ExamID = exam.ID
+	svcReq := h.MapCreateGradeBandRequestToExamGradeBandEntity(&req)
+
+	svcRes, err := h.gradingService.CreateGradeBand(svcReq)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapExamGradeBandEntityToGradeBandData(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) GetGradeBandsByExamSerial(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.gradingService.GetGradeBandsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapExamGradeBandEntityListToGradeBandDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) DeleteGradeBandByID(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	err := h.gradingService.DeleteGradeBandByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrGradeBandNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+/***
+	mapping
+***/
+
+func (h *handler) MapCreateGradeBandRequestToExamGradeBandEntity(req *CreateGradeBandRequest) *grading.ExamGradeBand {
+	return &grading.ExamGradeBand{
+		ExamID:   req.ExamID,
+		Label:    req.Label,
+		MinScore: req.MinScore,
+	}
+}
+
+func (h *handler) MapExamGradeBandEntityToGradeBandData(svcRes *grading.ExamGradeBand) *GradeBandData {
+	return &GradeBandData{
+		ID:       svcRes.ID,
+		Label:    svcRes.Label,
+		MinScore: svcRes.MinScore,
+	}
+}
+
+func (h *handler) MapExamGradeBandEntityListToGradeBandDataList(svcRes []*grading.ExamGradeBand) []*GradeBandData {
+	res := []*GradeBandData{}
+	for _, obj := range svcRes {
+		res = append(res, h.MapExamGradeBandEntityToGradeBandData(obj))
+	}
+	return res
+}
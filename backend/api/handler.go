@@ -3,13 +3,22 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/adminauth"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/analytics"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/answerkey"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/client/storage"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/deadletter"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/exam"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/examevent"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/grading"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/mcqoption"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/participantsession"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/question"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/questionbank"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/questionpool"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/receipt"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/reconciliation"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
 )
 
@@ -33,6 +42,44 @@ type Handler interface {
 	GetQuestionByID(*gin.Context)
 	UpdateQuestion(*gin.Context)
 	DeleteQuestionBySerial(*gin.Context)
+	ReorderQuestions(*gin.Context)
+
+	CreateBankQuestion(*gin.Context)
+	GetBankQuestions(*gin.Context)
+	GetBankQuestionByID(*gin.Context)
+	UpdateBankQuestion(*gin.Context)
+	DeleteBankQuestionByID(*gin.Context)
+	SnapshotBankQuestionsToExam(*gin.Context)
+
+	CreateExamQuestionPool(*gin.Context)
+	GetExamQuestionPoolsByExamSerial(*gin.Context)
+	DeleteExamQuestionPoolByID(*gin.Context)
+	GetParticipantQuestions(*gin.Context)
+
+	ReviseAnswerKey(*gin.Context)
+	GetAnswerKeyRevisionsByExamSerial(*gin.Context)
+	GetAnswerKeyRevisionByID(*gin.Context)
+	DownloadAnswerKeyRevision(*gin.Context)
+
+	CreateGradeBand(*gin.Context)
+	GetGradeBandsByExamSerial(*gin.Context)
+	DeleteGradeBandByID(*gin.Context)
+
+	GetItemAnalysis(*gin.Context)
+	GetExamStatistics(*gin.Context)
+	GetAnswerSimilarityPairs(*gin.Context)
+	RequestAnswerSimilarityDetection(*gin.Context)
+
+	GetDeadLetters(*gin.Context)
+	ReplayDeadLetter(*gin.Context)
+
+	ReconcileExamSubmissions(*gin.Context)
+	GetSubmissionReconciliationsByExamSerial(*gin.Context)
+
+	VerifyReceipt(*gin.Context)
+	VerifyReceiptAnswers(*gin.Context)
+	GetParticipantReceipt(*gin.Context)
+	GetExamSessionReceipt(*gin.Context)
 
 	// exam session auth
 	StartExam(*gin.Context)
@@ -44,6 +91,9 @@ type Handler interface {
 	GetQuestionsIDByExamSerial(*gin.Context)
 	GetQuestionWithOptions(*gin.Context)
 	SubmitAnswer(*gin.Context)
+	SyncAnswers(*gin.Context)
+	FlagQuestion(*gin.Context)
+	GetExamSessionSummary(*gin.Context)
 	SubmitExam(*gin.Context)
 
 	CreateMcqOption(*gin.Context)
@@ -54,12 +104,26 @@ type Handler interface {
 	CreateParticipant(*gin.Context)
 	GetParticipantsByExamSerial(*gin.Context)
 	GetParticipantByID(*gin.Context)
+	GetParticipantAnswerHistory(*gin.Context)
 	UpdateParticipant(*gin.Context)
 	DeleteParticipantByID(*gin.Context)
+	UpdateParticipantAccommodation(*gin.Context)
 	GetParticipantsReport(*gin.Context)
+	GetQuestionStatistics(*gin.Context)
 
 	LoginProctor(*gin.Context)
 	IsLoggedInAsProctor(*gin.Context)
+	CreateParticipantStateStreamToken(*gin.Context)
+	StreamParticipantStates(*gin.Context)
+	GetPendingParticipantSessions(*gin.Context)
+	BulkAuthorizeSessions(*gin.Context)
+	BulkRejectSessions(*gin.Context)
+	RejectSession(*gin.Context)
+	RevokeSession(*gin.Context)
+	GetParticipantSessionAudits(*gin.Context)
+	PauseParticipant(*gin.Context)
+	ResumeParticipant(*gin.Context)
+	ExtendParticipantTime(*gin.Context)
 }
 
 type handler struct {
@@ -72,6 +136,15 @@ type handler struct {
 	submissionService         submission.Service
 	storageService            storage.Service
 	participantSessionService participantsession.Service
+	questionBankService       questionbank.Service
+	questionPoolService       questionpool.Service
+	answerKeyService          answerkey.Service
+	gradingService            grading.Service
+	analyticsService          analytics.Service
+	examEventService          examevent.Service
+	deadLetterService         deadletter.Service
+	reconciliationService     reconciliation.Service
+	receiptService            receipt.Service
 }
 
 func NewHandler(
@@ -84,6 +157,15 @@ func NewHandler(
 	submissionService submission.Service,
 	storageService storage.Service,
 	participantSessionService participantsession.Service,
+	questionBankService questionbank.Service,
+	questionPoolService questionpool.Service,
+	answerKeyService answerkey.Service,
+	gradingService grading.Service,
+	analyticsService analytics.Service,
+	examEventService examevent.Service,
+	deadLetterService deadletter.Service,
+	reconciliationService reconciliation.Service,
+	receiptService receipt.Service,
 ) Handler {
 	return &handler{
 		cfg:                       cfg,
@@ -95,5 +177,14 @@ func NewHandler(
 		submissionService:         submissionService,
 		storageService:            storageService,
 		participantSessionService: participantSessionService,
+		questionBankService:       questionBankService,
+		questionPoolService:       questionPoolService,
+		answerKeyService:          answerKeyService,
+		gradingService:            gradingService,
+		analyticsService:          analyticsService,
+		examEventService:          examEventService,
+		deadLetterService:         deadLetterService,
+		reconciliationService:     reconciliationService,
+		receiptService:            receiptService,
 	}
 }
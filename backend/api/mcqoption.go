@@ -63,6 +63,8 @@ func (h *handler) CreateMcqOption(c *gin.Context) {
 		return
 	}
 
+	h.InvalidateExamStatisticsByQuestionID(svcRes.QuestionID)
+
 	res := h.MapMcqOptionEntityToMcqOptionData(svcRes)
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
@@ -99,15 +101,39 @@ func (h *handler) UpdateMcqOption(c *gin.Context) {
 
 	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
 	req.ID = uint(id)
+
+	isPointLocked, err := h.isMcqOptionPointLocked(req.ID, req.Point)
+	if err != nil {
+		if errors.Is(err, lib.ErrMcqOptionNotFound) || errors.Is(err, lib.ErrQuestionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	if isPointLocked {
+		c.JSON(http.StatusConflict, lib.BaseResponse{
+			Message: lib.ErrPointChangeRequiresRevision.Error(),
+		})
+		return
+	}
+
 	svcReq := h.MapUpdateMcqOptionRequestToMcqOptionEntity(&req)
 
-	err := h.mcqOptionService.UpdateMcqOption(svcReq)
+	err = h.mcqOptionService.UpdateMcqOption(svcReq)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: err.Error(),
 		})
 		return
 	}
+	if mcqOption, err := h.mcqOptionService.GetMcqOptionByID(req.ID); err == nil {
+		h.InvalidateExamStatisticsByQuestionID(mcqOption.QuestionID)
+	}
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
 	})
@@ -116,6 +142,10 @@ func (h *handler) UpdateMcqOption(c *gin.Context) {
 func (h *handler) DeleteMcqOptionByID(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
 
+	if mcqOption, err := h.mcqOptionService.GetMcqOptionByID(uint(id)); err == nil {
+		h.InvalidateExamStatisticsByQuestionID(mcqOption.QuestionID)
+	}
+
 	err := h.mcqOptionService.DeleteMcqOptionByID(uint(id))
 	if err != nil {
 		if errors.Is(err, lib.ErrMcqOptionNotFound) {
@@ -134,6 +164,32 @@ func (h *handler) DeleteMcqOptionByID(c *gin.Context) {
 	})
 }
 
+// isMcqOptionPointLocked tells whether the new point changes the option of an exam some participant already started,
+// such a change has to go through ReviseAnswerKey so it is recorded along with the changed scores.
+func (h *handler) isMcqOptionPointLocked(id uint, point int) (bool, error) {
+	mcqOption, err := h.mcqOptionService.GetMcqOptionByID(id)
+	if err != nil {
+		return false, err
+	}
+	if mcqOption.Point == point {
+		return false, nil
+	}
+	question, err := h.questionService.GetQuestionByID(mcqOption.QuestionID)
+	if err != nil {
+		return false, err
+	}
+	participants, err := h.participantService.GetParticipantsByExamID(question.ExamID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range participants {
+		if p.StartedAt != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 /***
 	mapping
 ***/
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/adminauth"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/examsession"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
 )
@@ -85,6 +86,32 @@ func JWTProctorMiddleware(adminAuthService adminauth.Service) gin.HandlerFunc {
 	}
 }
 
+// ProctorStreamTokenMiddleware authorizes the participant state stream with the stream token in the query string, since EventSource can not set the Authorization header.
+func ProctorStreamTokenMiddleware(adminAuthService adminauth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := c.Query(constants.Token)
+		if tokenString == "" {
+			c.JSON(http.StatusUnauthorized, lib.BaseResponse{
+				Message: ErrUnauthorizedRequest.Error(),
+			})
+			c.Abort()
+			return
+		}
+		claims, err := adminAuthService.ValidateProctorStreamToken(tokenString, c.Param(constants.Serial))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set(constants.JWTClaims, claims)
+
+		c.Next()
+	}
+}
+
 func JWTExamTokenMiddleware(participantService participant.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authorizationHeader := c.GetHeader("Authorization")
@@ -110,3 +137,54 @@ func JWTExamTokenMiddleware(participantService participant.Service) gin.HandlerF
 		c.Next()
 	}
 }
+
+// ExamSessionMiddleware must run after JWTExamTokenMiddleware, the validated exam session is then available through getExamSessionFromContext.
+func ExamSessionMiddleware(examSessionService examsession.Service, requirement *examsession.Requirement) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := lib.GetExamTokenJWTClaimsFromContext(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, lib.BaseResponse{
+				Message: ErrUnauthorizedRequest.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		examSession, err := examSessionService.ValidateExamSession(claims, c.Param(constants.Serial), requirement)
+		if err != nil {
+			c.JSON(getExamSessionErrorStatus(err), lib.BaseResponse{
+				Message: err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set(constants.ExamSession, examSession)
+
+		c.Next()
+	}
+}
+
+func getExamSessionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, lib.ErrExamNotStarted), errors.Is(err, lib.ErrExamAlreadySubmitted), errors.Is(err, lib.ErrExamPaused):
+		return http.StatusBadRequest
+	case errors.Is(err, lib.ErrUnauthorizedRequest):
+		return http.StatusUnauthorized
+	case errors.Is(err, lib.ErrSessionRevoked), errors.Is(err, lib.ErrSessionRejected):
+		return http.StatusForbidden
+	case errors.Is(err, lib.ErrParticipantNotFound), errors.Is(err, lib.ErrExamNotFound), errors.Is(err, lib.ErrSessionNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func getExamSessionFromContext(c *gin.Context) (*examsession.ExamSession, error) {
+	if val, exists := c.Get(constants.ExamSession); exists {
+		if res, ok := val.(*examsession.ExamSession); ok {
+			return res, nil
+		}
+	}
+	return nil, lib.ErrUnknownError
+}
@@ -11,9 +11,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/exam"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/examevent"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/grading"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/participantsession"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/receipt"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
 	"gorm.io/gorm"
 )
 
@@ -36,7 +41,15 @@ type ParticipantData struct {
 	AllowedDurationMinutes uint       `json:"allowed_duration_minutes"`
 	IsExamStarted          bool       `json:"is_exam_started"`
 	IsSubmitted            bool       `json:"is_submitted"`
+	IsPaused               bool       `json:"is_paused"`
+	ExtendedMinutes        uint       `json:"extended_minutes"`
+	TimeMultiplier         float64    `json:"time_multiplier"`
+	AccommodationNote      string     `json:"accommodation_note"`
 	TotalPoint             int        `json:"total_point"`
+	MaxPoint               int        `json:"max_point"`
+	NormalizedScore        *float64   `json:"normalized_score"` // null with the other grade fields while the exam is not reconciled
+	IsPassed               *bool      `json:"is_passed"`
+	Grade                  string     `json:"grade"`
 }
 
 type UpdateParticipantRequest struct {
@@ -45,6 +58,11 @@ type UpdateParticipantRequest struct {
 	AllowedDurationMinutes uint   `json:"allowed_duration_minutes" binding:"required"`
 }
 
+type UpdateParticipantAccommodationRequest struct {
+	TimeMultiplier    float64 `json:"time_multiplier" binding:"required,gt=0"`
+	AccommodationNote string  `json:"accommodation_note"`
+}
+
 type StartExamRequest struct {
 	ExamID uint   `json:"-"`
 	Name   string `json:"name" binding:"required"`
@@ -55,6 +73,22 @@ type StartExamResponse struct {
 	SessionSerial string `json:"session"`
 }
 
+type QuestionStatisticsData struct {
+	QuestionID    uint    `json:"question_id"`
+	ServedCount   int     `json:"served_count"`
+	AnsweredCount int     `json:"answered_count"`
+	CorrectCount  int     `json:"correct_count"`
+	MeanPoint     float64 `json:"mean_point"`
+}
+
+type AnswerHistoryData struct {
+	QuestionID    uint      `json:"question_id"`
+	McqOptionID   uint      `json:"mcq_option_id"`
+	SessionSerial string    `json:"session_serial"`
+	Sequence      int64     `json:"sequence"`
+	AnsweredAt    time.Time `json:"answered_at"`
+}
+
 /***
 	handler
 ***/
@@ -133,7 +167,24 @@ func (h *handler) GetParticipantsByExamSerial(c *gin.Context) {
 		return
 	}
 
-	res := h.MapGetParticipantsByExamSerialResponse(svcRes, totalPoints)
+	gradeBands, err := h.gradingService.GetGradeBandsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// the grades are left out rather than shown from answers which may still be missing in the database
+	isReconciled, err := h.reconciliationService.IsExamReconciled(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapGetParticipantsByExamSerialResponse(svcRes, totalPoints, exam, gradeBands, isReconciled)
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
 		Data:    res,
@@ -158,6 +209,38 @@ func (h *handler) GetParticipantByID(c *gin.Context) {
 	})
 }
 
+func (h *handler) GetParticipantAnswerHistory(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	participant, err := h.participantService.GetParticipantByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.submissionService.GetAnswerHistory(participant.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapSubmissionEventEntityListToAnswerHistoryDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
 func (h *handler) UpdateParticipant(c *gin.Context) {
 	var req UpdateParticipantRequest
 
@@ -179,6 +262,36 @@ func (h *handler) UpdateParticipant(c *gin.Context) {
 		})
 		return
 	}
+	h.analyticsService.InvalidateExamStatisticsByParticipantID(req.ID)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+func (h *handler) UpdateParticipantAccommodation(c *gin.Context) {
+	var req UpdateParticipantAccommodationRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+	err := h.participantService.UpdateParticipantAccommodation(uint(id), req.TimeMultiplier, req.AccommodationNote)
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
 	})
@@ -187,6 +300,8 @@ func (h *handler) UpdateParticipant(c *gin.Context) {
 func (h *handler) DeleteParticipantByID(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
 
+	h.analyticsService.InvalidateExamStatisticsByParticipantID(uint(id))
+
 	err := h.participantService.DeleteParticipantByID(uint(id))
 	if err != nil {
 		if errors.Is(err, lib.ErrParticipantNotFound) {
@@ -251,13 +366,21 @@ func (h *handler) StartExam(c *gin.Context) {
 		return
 	}
 
-	if participant.EndedAt != nil || (participant.StartedAt != nil && participant.StartedAt.Add(time.Duration(participant.AllowedDurationMinutes)*time.Minute).Before(time.Now())) {
+	if participant.IsSubmitted() {
 		c.JSON(http.StatusBadRequest, lib.BaseResponse{
 			Message: lib.ErrExamAlreadySubmitted.Error(),
 		})
 		return
 	}
 
+	_, err = h.questionPoolService.DrawParticipantQuestions(participant.ID, exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
 	participantSession, err := h.participantSessionService.CreateParticipantSession(&participantsession.ParticipantSession{
 		ParticipantID: participant.ID,
 	})
@@ -268,6 +391,8 @@ func (h *handler) StartExam(c *gin.Context) {
 		return
 	}
 
+	h.examEventService.Publish(examevent.ExamStarted, exam.ID, participant.ID)
+
 	// generate exam token
 	examToken := h.participantService.GenerateToken(exam.Serial, participant.ID, participantSession.Serial)
 	c.JSON(http.StatusOK, lib.BaseResponse{
@@ -280,48 +405,25 @@ func (h *handler) StartExam(c *gin.Context) {
 }
 
 func (h *handler) SubmitExam(c *gin.Context) {
-	jwtClaims, err := lib.GetExamTokenJWTClaimsFromContext(c)
+	examSession, err := getExamSessionFromContext(c)
 	if err != nil {
-		log.Printf("[handler][participant][SubmitExam] error when get jwt: %s", err.Error())
+		log.Printf("[handler][participant][SubmitExam] error when get exam session: %s", err.Error())
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: lib.ErrUnknownError.Error(),
 		})
 		return
 	}
 
-	participant, err := h.participantService.GetParticipantByID(jwtClaims.ParticipantID)
-	if err != nil {
-		if errors.Is(err, lib.ErrParticipantNotFound) {
-			c.JSON(http.StatusNotFound, lib.BaseResponse{
-				Message: err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
-			Message: err.Error(),
-		})
-		return
-	}
-
-	if participant.StartedAt == nil {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrExamNotStarted.Error(),
-		})
-		return
-	}
-
-	if participant.EndedAt != nil || participant.StartedAt.Add(time.Duration(participant.AllowedDurationMinutes)*time.Minute).Before(time.Now()) {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrExamAlreadySubmitted.Error(),
-		})
-		return
-	}
+	participant := examSession.Participant
+	exam := examSession.Exam
 
-	exam, err := h.examService.GetExamByID(participant.ExamID)
+	// ended only while not ended yet, so a concurrent finalization of the deadline is kept
+	currentTime := time.Now()
+	err = h.participantService.EndParticipant(participant.ID, currentTime)
 	if err != nil {
-		if errors.Is(err, lib.ErrExamNotFound) {
-			c.JSON(http.StatusNotFound, lib.BaseResponse{
-				Message: err.Error(),
+		if errors.Is(err, lib.ErrParticipantAlreadyEnded) {
+			c.JSON(http.StatusBadRequest, lib.BaseResponse{
+				Message: lib.ErrExamAlreadySubmitted.Error(),
 			})
 			return
 		}
@@ -330,105 +432,43 @@ func (h *handler) SubmitExam(c *gin.Context) {
 		})
 		return
 	}
-	if !exam.IsOpen || exam.Serial != c.Param(constants.Serial) {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrExamNotFound.Error(),
-		})
-		return
+	participant.EndedAt = &currentTime
+	h.examEventService.Publish(examevent.ExamSubmitted, exam.ID, participant.ID)
+
+	// the participant is submitted from here on, so no new answer is accepted, and none is written once the receipt exists.
+	// A receipt which fails here is issued by the worker later, and is fetched through GetExamSessionReceipt.
+	var res *SubmissionReceiptData
+	questionIDs, err := h.questionPoolService.GetServedQuestionIDs(participant.ID, exam.ID)
+	if err == nil {
+		var svcRes *receipt.SubmissionReceipt
+		svcRes, err = h.receiptService.IssueReceipt(participant, questionIDs)
+		if err == nil {
+			res = h.MapSubmissionReceiptEntityToSubmissionReceiptData(svcRes)
+		}
 	}
-	participantSession, err := h.participantSessionService.GetLatestAuthorizedParticipantSessionByParticipantID(participant.ID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
-		})
-		return
-	}
-	if participantSession.Serial != jwtClaims.SessionSerial {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
-		})
-		return
+		log.Printf("[handler][participant][SubmitExam] failed to issue receipt of participant %d: %s", participant.ID, err.Error())
 	}
+	// the statistics only change on submit, and are dropped once issuing the receipt flushed the answers
+	h.analyticsService.InvalidateExamStatistics(exam.ID)
 
-	currentTime := time.Now()
-	participant.EndedAt = &currentTime
-	err = h.participantService.UpdateParticipant(participant)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
-			Message: err.Error(),
-		})
-		return
-	}
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
+		Data:    res,
 	})
 }
 
 func (h *handler) IsSessionAuthorized(c *gin.Context) {
-	// return success, 404, 401, or 500 depending on if the request exam token session serial is authorized or not
-	jwtClaims, err := lib.GetExamTokenJWTClaimsFromContext(c)
+	// the exam session middleware already returned 404, 403, 400 or 500 if the request exam token session serial is not authorized
+	_, err := getExamSessionFromContext(c)
 	if err != nil {
-		log.Printf("[handler][participant][IsSessionAuthorized] error when get jwt: %s", err.Error())
+		log.Printf("[handler][participant][IsSessionAuthorized] error when get exam session: %s", err.Error())
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: lib.ErrUnknownError.Error(),
 		})
 		return
 	}
 
-	participant, err := h.participantService.GetParticipantByID(jwtClaims.ParticipantID)
-	if err != nil {
-		if errors.Is(err, lib.ErrParticipantNotFound) {
-			c.JSON(http.StatusNotFound, lib.BaseResponse{
-				Message: err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
-			Message: err.Error(),
-		})
-		return
-	}
-
-	if participant.EndedAt != nil {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrExamAlreadySubmitted.Error(),
-		})
-		return
-	}
-
-	exam, err := h.examService.GetExamByID(participant.ExamID)
-	if err != nil {
-		if errors.Is(err, lib.ErrExamNotFound) {
-			c.JSON(http.StatusNotFound, lib.BaseResponse{
-				Message: err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
-			Message: err.Error(),
-		})
-		return
-	}
-	if !exam.IsOpen || exam.Serial != c.Param(constants.Serial) {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrExamNotFound.Error(),
-		})
-		return
-	}
-	participantSession, err := h.participantSessionService.GetLatestAuthorizedParticipantSessionByParticipantID(participant.ID)
-	if err != nil {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
-		})
-		return
-	}
-	if participantSession.Serial != jwtClaims.SessionSerial {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
-		})
-		return
-	}
-
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
 	})
@@ -466,7 +506,19 @@ func (h *handler) GetParticipantsReport(c *gin.Context) {
 		return
 	}
 
-	processedParticipants := h.MapGetParticipantsByExamSerialResponse(participants, totalPoints)
+	if !h.ensureExamReconciled(c, exam.ID) {
+		return
+	}
+
+	gradeBands, err := h.gradingService.GetGradeBandsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	processedParticipants := h.MapGetParticipantsByExamSerialResponse(participants, totalPoints, exam, gradeBands, true)
 
 	questionsIDList, err := h.questionService.GetQuestionsIDByExamID(exam.ID)
 	if err != nil {
@@ -491,8 +543,23 @@ func (h *handler) GetParticipantsReport(c *gin.Context) {
 		mapParticipantsAnswers[participantAnswer.ParticipantID][participantAnswer.QuestionID] = participantAnswer
 	}
 
+	participantQuestions, err := h.questionPoolService.GetParticipantQuestionsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	mapParticipantQuestions := map[uint]map[uint]bool{}
+	for _, participantQuestion := range participantQuestions {
+		if _, ok := mapParticipantQuestions[participantQuestion.ParticipantID]; !ok {
+			mapParticipantQuestions[participantQuestion.ParticipantID] = map[uint]bool{}
+		}
+		mapParticipantQuestions[participantQuestion.ParticipantID][participantQuestion.QuestionID] = true
+	}
+
 	res := [][]string{}
-	header := []string{"kode_peserta", "total_poin", "durasi", "waktu_mulai"}
+	header := []string{"kode_peserta", "total_poin", "poin_maksimal", "nilai", "lulus", "predikat", "durasi", "waktu_mulai"}
 	for i := range questionsIDList {
 		header = append(header, fmt.Sprintf("jawaban_soal_%d", i+1))
 		header = append(header, fmt.Sprintf("poin_soal_%d", i+1))
@@ -500,7 +567,23 @@ func (h *handler) GetParticipantsReport(c *gin.Context) {
 	res = append(res, header)
 
 	for _, p := range processedParticipants {
-		row := []string{p.Name, fmt.Sprintf("%d", p.TotalPoint), fmt.Sprintf("%d", p.AllowedDurationMinutes)}
+		isPassed := "-"
+		if p.IsPassed != nil {
+			if *p.IsPassed {
+				isPassed = "ya"
+			} else {
+				isPassed = "tidak"
+			}
+		}
+		row := []string{
+			p.Name,
+			fmt.Sprintf("%d", p.TotalPoint),
+			fmt.Sprintf("%d", p.MaxPoint),
+			fmt.Sprintf("%.2f", *p.NormalizedScore),
+			isPassed,
+			p.Grade,
+			fmt.Sprintf("%d", p.AllowedDurationMinutes+p.ExtendedMinutes),
+		}
 		if p.StartedAt == nil {
 			row = append(row, "-")
 		} else {
@@ -508,8 +591,18 @@ func (h *handler) GetParticipantsReport(c *gin.Context) {
 		}
 
 		for i := range questionsIDList {
+			// questions outside the participant's drawn set are left empty
+			if servedQuestions, ok := mapParticipantQuestions[p.ID]; ok && !servedQuestions[questionsIDList[i].ID] {
+				row = append(row, "")
+				row = append(row, "")
+				continue
+			}
 			if ans, ok := mapParticipantsAnswers[p.ID][questionsIDList[i].ID]; ok {
-				row = append(row, ans.Answer)
+				if ans.IsAnswered {
+					row = append(row, ans.Answer)
+				} else {
+					row = append(row, "-")
+				}
 				row = append(row, fmt.Sprintf("%d", ans.Point))
 			} else {
 				row = append(row, "-")
@@ -535,6 +628,40 @@ func (h *handler) GetParticipantsReport(c *gin.Context) {
 	writer.Flush()
 }
 
+func (h *handler) GetQuestionStatistics(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if !h.ensureExamReconciled(c, exam.ID) {
+		return
+	}
+
+	svcRes, err := h.participantService.GetQuestionStatisticsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapQuestionStatisticsEntityListToQuestionStatisticsDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
 /***
 	mapping
 ***/
@@ -558,6 +685,10 @@ func (h *handler) MapParticipantEntityToParticipantData(svcRes *participant.Part
 		StartedAt:              svcRes.StartedAt,
 		EndedAt:                svcRes.EndedAt,
 		AllowedDurationMinutes: svcRes.AllowedDurationMinutes,
+		IsPaused:               svcRes.IsPaused(),
+		ExtendedMinutes:        svcRes.ExtendedMinutes,
+		TimeMultiplier:         svcRes.TimeMultiplier,
+		AccommodationNote:      svcRes.AccommodationNote,
 	}
 }
 
@@ -581,20 +712,58 @@ func (h *handler) MapUpdateParticipantRequestToParticipantEntity(req *UpdatePart
 	}
 }
 
-func (h *handler) MapGetParticipantsByExamSerialResponse(svcRes []*participant.Participant, totalPoints []*participant.ParticipantTotalPoint) []*ParticipantData {
+func (h *handler) MapGetParticipantsByExamSerialResponse(svcRes []*participant.Participant, totalPoints []*participant.ParticipantTotalPoint, examData *exam.Exam, gradeBands []*grading.ExamGradeBand, isGraded bool) []*ParticipantData {
 	participantData := h.MapParticipantEntityListToParticipantDataList(svcRes)
-	totalPointsMap := map[uint]int{}
+	totalPointsMap := map[uint]*participant.ParticipantTotalPoint{}
 	for i := range totalPoints {
-		totalPointsMap[totalPoints[i].ParticipantID] = totalPoints[i].TotalPoint
+		totalPointsMap[totalPoints[i].ParticipantID] = totalPoints[i]
 	}
 	for i := range participantData {
-		participantData[i].TotalPoint = totalPointsMap[participantData[i].ID]
+		if totalPoint, ok := totalPointsMap[participantData[i].ID]; ok {
+			participantData[i].TotalPoint = totalPoint.TotalPoint
+			participantData[i].MaxPoint = totalPoint.MaxPoint
+		}
+		if isGraded {
+			grade := h.gradingService.GradeParticipant(examData, gradeBands, participantData[i].TotalPoint, participantData[i].MaxPoint)
+			participantData[i].NormalizedScore = &grade.NormalizedScore
+			participantData[i].IsPassed = grade.IsPassed
+			participantData[i].Grade = grade.Label
+		}
 		if participantData[i].StartedAt != nil {
 			participantData[i].IsExamStarted = true
-			if participantData[i].EndedAt != nil || participantData[i].StartedAt.Add(time.Duration(participantData[i].AllowedDurationMinutes)*time.Minute).Before(time.Now()) {
-				participantData[i].IsSubmitted = true
-			}
+			participantData[i].IsSubmitted = svcRes[i].IsSubmitted()
 		}
 	}
 	return participantData
 }
+
+func (h *handler) MapQuestionStatisticsEntityListToQuestionStatisticsDataList(svcRes []*participant.QuestionStatistics) []*QuestionStatisticsData {
+	res := []*QuestionStatisticsData{}
+	for _, obj := range svcRes {
+		data := &QuestionStatisticsData{
+			QuestionID:    obj.QuestionID,
+			ServedCount:   obj.ServedCount,
+			AnsweredCount: obj.AnsweredCount,
+			CorrectCount:  obj.CorrectCount,
+		}
+		if obj.ServedCount > 0 {
+			data.MeanPoint = float64(obj.TotalPoint) / float64(obj.ServedCount)
+		}
+		res = append(res, data)
+	}
+	return res
+}
+
+func (h *handler) MapSubmissionEventEntityListToAnswerHistoryDataList(svcRes []*submission.SubmissionEvent) []*AnswerHistoryData {
+	res := []*AnswerHistoryData{}
+	for _, obj := range svcRes {
+		res = append(res, &AnswerHistoryData{
+			QuestionID:    obj.QuestionID,
+			McqOptionID:   obj.McqOptionID,
+			SessionSerial: obj.SessionSerial,
+			Sequence:      obj.Sequence,
+			AnsweredAt:    obj.AnsweredAt,
+		})
+	}
+	return res
+}
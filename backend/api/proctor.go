@@ -2,12 +2,20 @@ package api
 
 import (
 	"errors"
+	"io"
+	"log"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/exam"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/examevent"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participantsession"
 )
 
 /***
@@ -17,6 +25,8 @@ import (
 type CheckSessionResponse struct {
 	IsStartExam bool             `json:"is_start_exam"`
 	IsSubmitted bool             `json:"is_submitted"`
+	IsRejected  bool             `json:"is_rejected"`
+	IsRevoked   bool             `json:"is_revoked"`
 	Participant *ParticipantData `json:"participant"`
 	Exam        *ExamData        `json:"exam"`
 }
@@ -25,6 +35,64 @@ type AuthorizeSessionRequest struct {
 	AllowedDurationMinutes uint `json:"allowed_duration_minutes" binding:"required"`
 }
 
+type PendingParticipantSessionData struct {
+	SessionSerial   string    `json:"session_serial"`
+	ParticipantID   uint      `json:"participant_id"`
+	ParticipantName string    `json:"participant_name"`
+	RequestedAt     time.Time `json:"requested_at"`
+}
+
+type BulkAuthorizeSessionsRequest struct {
+	SessionSerials         []string `json:"session_serials"`
+	All                    bool     `json:"all"` // every pending session of the exam, ignoring session_serials
+	AllowedDurationMinutes uint     `json:"allowed_duration_minutes" binding:"required"`
+}
+
+type BulkRejectSessionsRequest struct {
+	SessionSerials []string `json:"session_serials"`
+	All            bool     `json:"all"` // every pending session of the exam, ignoring session_serials
+	Reason         string   `json:"reason" binding:"required"`
+}
+
+type SessionActionRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type ParticipantSessionAuditData struct {
+	ID                   uint      `json:"id"`
+	ParticipantSessionID uint      `json:"participant_session_id"`
+	ParticipantID        uint      `json:"participant_id"`
+	ParticipantName      string    `json:"participant_name"`
+	Action               string    `json:"action"`
+	Reason               string    `json:"reason"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+type BulkSessionActionResultData struct {
+	SessionSerial string `json:"session_serial"`
+	IsSuccess     bool   `json:"is_success"`
+	Message       string `json:"message"`
+}
+
+type ExtendParticipantTimeRequest struct {
+	Minutes uint   `json:"minutes" binding:"required"`
+	Reason  string `json:"reason" binding:"required"`
+}
+
+type ParticipantStateData struct {
+	ParticipantID    uint       `json:"participant_id"`
+	Name             string     `json:"name"`
+	State            string     `json:"state"`
+	AnsweredCount    int        `json:"answered_count"`
+	QuestionCount    int        `json:"question_count"`
+	StartedAt        *time.Time `json:"started_at"`
+	RemainingSeconds *int64     `json:"remaining_seconds"` // null until the first session is authorized
+}
+
+type ParticipantStateStreamTokenResponse struct {
+	Token string `json:"token"`
+}
+
 /***
 	handler
 ***/
@@ -84,7 +152,10 @@ func (h *handler) CheckSession(c *gin.Context) {
 		return
 	}
 
-	res := &CheckSessionResponse{}
+	res := &CheckSessionResponse{
+		IsRejected: participantSession.RejectedAt != nil,
+		IsRevoked:  participantSession.RevokedAt != nil,
+	}
 
 	participant, err := h.participantService.GetParticipantByID(participantSession.ParticipantID)
 	if err != nil {
@@ -102,9 +173,7 @@ func (h *handler) CheckSession(c *gin.Context) {
 	if participant.StartedAt == nil {
 		res.IsStartExam = true
 	} else {
-		if participant.EndedAt != nil || participant.StartedAt.Add(time.Duration(participant.AllowedDurationMinutes)*time.Minute).Before(time.Now()) {
-			res.IsSubmitted = true
-		}
+		res.IsSubmitted = participant.IsSubmitted()
 	}
 	res.Participant = h.MapParticipantEntityToParticipantData(participant)
 
@@ -157,11 +226,631 @@ func (h *handler) AuthorizeSession(c *gin.Context) {
 		})
 		return
 	}
+
+	participantSession, err := h.participantSessionService.GetParticipantSessionBySerial(c.Param(constants.Serial))
+	if err == nil {
+		participant, err := h.participantService.GetParticipantByID(participantSession.ParticipantID)
+		if err == nil {
+			h.examEventService.Publish(examevent.SessionAuthorized, participant.ExamID, participant.ID)
+		}
+	}
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
 	})
 }
 
+func (h *handler) GetPendingParticipantSessions(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	pendingSessions, err := h.participantSessionService.GetPendingParticipantSessionsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	participants, err := h.participantService.GetParticipantsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    h.MapParticipantSessionEntityListToPendingParticipantSessionDataList(pendingSessions, participants),
+	})
+}
+
+func (h *handler) BulkAuthorizeSessions(c *gin.Context) {
+	var req BulkAuthorizeSessionsRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	exam, pendingSessions, ok := h.getExamPendingParticipantSessions(c)
+	if !ok {
+		return
+	}
+
+	res := []*BulkSessionActionResultData{}
+	for _, serial := range h.getBulkSessionSerials(req.SessionSerials, req.All, pendingSessions) {
+		// only pending sessions of this exam can be authorized in bulk
+		pendingSession, ok := pendingSessions[serial]
+		if !ok {
+			res = append(res, &BulkSessionActionResultData{
+				SessionSerial: serial,
+				Message:       lib.ErrParticipantSessionNotFound.Error(),
+			})
+			continue
+		}
+
+		err := h.participantSessionService.AuthorizeSession(serial, req.AllowedDurationMinutes)
+		if err != nil {
+			res = append(res, &BulkSessionActionResultData{
+				SessionSerial: serial,
+				Message:       err.Error(),
+			})
+			continue
+		}
+		h.examEventService.Publish(examevent.SessionAuthorized, exam.ID, pendingSession.ParticipantID)
+		res = append(res, &BulkSessionActionResultData{
+			SessionSerial: serial,
+			IsSuccess:     true,
+			Message:       constants.Success,
+		})
+	}
+
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) BulkRejectSessions(c *gin.Context) {
+	var req BulkRejectSessionsRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	exam, pendingSessions, ok := h.getExamPendingParticipantSessions(c)
+	if !ok {
+		return
+	}
+
+	res := []*BulkSessionActionResultData{}
+	for _, serial := range h.getBulkSessionSerials(req.SessionSerials, req.All, pendingSessions) {
+		pendingSession, ok := pendingSessions[serial]
+		if !ok {
+			res = append(res, &BulkSessionActionResultData{
+				SessionSerial: serial,
+				Message:       lib.ErrParticipantSessionNotFound.Error(),
+			})
+			continue
+		}
+
+		err := h.participantSessionService.RejectSession(serial, req.Reason)
+		if err != nil {
+			res = append(res, &BulkSessionActionResultData{
+				SessionSerial: serial,
+				Message:       err.Error(),
+			})
+			continue
+		}
+		h.examEventService.Publish(examevent.SessionRejected, exam.ID, pendingSession.ParticipantID)
+		res = append(res, &BulkSessionActionResultData{
+			SessionSerial: serial,
+			IsSuccess:     true,
+			Message:       constants.Success,
+		})
+	}
+
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) RejectSession(c *gin.Context) {
+	h.handleSessionAction(c, h.participantSessionService.RejectSession, examevent.SessionRejected)
+}
+
+func (h *handler) RevokeSession(c *gin.Context) {
+	h.handleSessionAction(c, h.participantSessionService.RevokeSession, examevent.SessionRevoked)
+}
+
+func (h *handler) handleSessionAction(c *gin.Context, action func(serial string, reason string) error, eventType string) {
+	var req SessionActionRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	participantSession, err := h.participantSessionService.GetParticipantSessionBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantSessionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	err = action(participantSession.Serial, req.Reason)
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantSessionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	participant, err := h.participantService.GetParticipantByID(participantSession.ParticipantID)
+	if err == nil {
+		h.examEventService.Publish(eventType, participant.ExamID, participant.ID)
+	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+func (h *handler) GetParticipantSessionAudits(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	audits, err := h.participantSessionService.GetParticipantSessionAuditsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	participants, err := h.participantService.GetParticipantsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := []*ParticipantSessionAuditData{}
+	participantNames := map[uint]string{}
+	for _, p := range participants {
+		participantNames[p.ID] = p.Name
+	}
+	for _, audit := range audits {
+		data := h.MapParticipantSessionAuditEntityToParticipantSessionAuditData(audit)
+		data.ParticipantName = participantNames[audit.ParticipantID]
+		res = append(res, data)
+	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) PauseParticipant(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	participant, err := h.participantService.GetParticipantByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	if participant.StartedAt == nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrExamNotStarted.Error(),
+		})
+		return
+	}
+	if participant.IsSubmitted() {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrExamAlreadySubmitted.Error(),
+		})
+		return
+	}
+
+	err = h.participantService.PauseParticipant(participant.ID)
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantAlreadyPaused) {
+			c.JSON(http.StatusBadRequest, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	h.examEventService.Publish(examevent.ParticipantPaused, participant.ExamID, participant.ID)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+func (h *handler) ExtendParticipantTime(c *gin.Context) {
+	var req ExtendParticipantTimeRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+	participant, err := h.participantService.GetParticipantByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	// extensions are for running participants, the duration before start is set when authorizing
+	if participant.StartedAt == nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrExamNotStarted.Error(),
+		})
+		return
+	}
+	if participant.IsSubmitted() {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrExamAlreadySubmitted.Error(),
+		})
+		return
+	}
+
+	err = h.participantService.ExtendParticipantTime(participant.ID, req.Minutes, req.Reason)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	h.examEventService.Publish(examevent.ParticipantTimeExtended, participant.ExamID, participant.ID)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+func (h *handler) ResumeParticipant(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	participant, err := h.participantService.GetParticipantByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	err = h.participantService.ResumeParticipant(participant.ID)
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantNotPaused) {
+			c.JSON(http.StatusBadRequest, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	h.examEventService.Publish(examevent.ParticipantResumed, participant.ExamID, participant.ID)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+// getExamPendingParticipantSessions writes the error response itself and returns false when the exam or its pending sessions cannot be fetched.
+func (h *handler) getExamPendingParticipantSessions(c *gin.Context) (*exam.Exam, map[string]*participantsession.ParticipantSession, bool) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return nil, nil, false
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	pendingSessions, err := h.participantSessionService.GetPendingParticipantSessionsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	res := map[string]*participantsession.ParticipantSession{}
+	for _, pendingSession := range pendingSessions {
+		res[pendingSession.Serial] = pendingSession
+	}
+	return exam, res, true
+}
+
+func (h *handler) getBulkSessionSerials(sessionSerials []string, all bool, pendingSessions map[string]*participantsession.ParticipantSession) []string {
+	if !all {
+		return sessionSerials
+	}
+	res := []string{}
+	for serial := range pendingSessions {
+		res = append(res, serial)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (h *handler) CreateParticipantStateStreamToken(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data: &ParticipantStateStreamTokenResponse{
+			Token: h.adminAuthService.GenerateProctorStreamToken(exam.Serial),
+		},
+	})
+}
+
+func (h *handler) StreamParticipantStates(c *gin.Context) {
+	// server-sent events: a snapshot of every participant on connect and per refresh interval,
+	// then the participants touched by exam events in between
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	events, err := h.examEventService.Subscribe(c.Request.Context(), exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	states, err := h.getParticipantStates(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent(constants.SnapshotEvent, states)
+	c.Writer.Flush()
+
+	flushTicker := time.NewTicker(h.cfg.ProctorDashboardFlushInterval)
+	defer flushTicker.Stop()
+	refreshTicker := time.NewTicker(h.cfg.ProctorDashboardRefreshInterval)
+	defer refreshTicker.Stop()
+
+	changedParticipantIDs := map[uint]bool{}
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			changedParticipantIDs[event.ParticipantID] = true
+		case <-flushTicker.C:
+			if len(changedParticipantIDs) == 0 {
+				return true
+			}
+			states, err := h.getParticipantStates(exam.ID)
+			if err != nil {
+				log.Printf("[handler][proctor][StreamParticipantStates] error when get participant states: %s", err.Error())
+				return true
+			}
+			for _, state := range states {
+				if changedParticipantIDs[state.ParticipantID] {
+					c.SSEvent(constants.ParticipantEvent, state)
+				}
+			}
+			changedParticipantIDs = map[uint]bool{}
+		case <-refreshTicker.C:
+			states, err := h.getParticipantStates(exam.ID)
+			if err != nil {
+				log.Printf("[handler][proctor][StreamParticipantStates] error when get participant states: %s", err.Error())
+				return true
+			}
+			c.SSEvent(constants.SnapshotEvent, states)
+			changedParticipantIDs = map[uint]bool{}
+		}
+		return true
+	})
+}
+
+func (h *handler) getParticipantStates(examID uint) ([]*ParticipantStateData, error) {
+	participants, err := h.participantService.GetParticipantsByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	progresses, err := h.participantService.GetParticipantProgressesByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	pendingSessions, err := h.participantSessionService.GetPendingParticipantSessionsByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	return h.MapParticipantEntityListToParticipantStateDataList(participants, progresses, pendingSessions, time.Now()), nil
+}
+
 /***
 	mapping
 ***/
+
+func (h *handler) MapParticipantEntityListToParticipantStateDataList(participants []*participant.Participant, progresses []*participant.ParticipantProgress, pendingSessions []*participantsession.ParticipantSession, now time.Time) []*ParticipantStateData {
+	progressByParticipantID := map[uint]*participant.ParticipantProgress{}
+	for _, progress := range progresses {
+		progressByParticipantID[progress.ParticipantID] = progress
+	}
+	isPendingByParticipantID := map[uint]bool{}
+	for _, pendingSession := range pendingSessions {
+		isPendingByParticipantID[pendingSession.ParticipantID] = true
+	}
+
+	res := []*ParticipantStateData{}
+	for _, p := range participants {
+		state := &ParticipantStateData{
+			ParticipantID: p.ID,
+			Name:          p.Name,
+			StartedAt:     p.StartedAt,
+		}
+		if progress, ok := progressByParticipantID[p.ID]; ok {
+			state.AnsweredCount = progress.AnsweredCount
+			state.QuestionCount = progress.ServedCount
+		}
+
+		if p.StartedAt != nil {
+			remainingSeconds := int64(p.Deadline(now).Sub(now).Seconds())
+			if remainingSeconds < 0 || p.EndedAt != nil {
+				remainingSeconds = 0
+			}
+			state.RemainingSeconds = &remainingSeconds
+		}
+
+		switch {
+		case p.EndedAt != nil:
+			state.State = constants.ParticipantStateSubmitted
+		case p.IsSubmitted():
+			state.State = constants.ParticipantStateExpired
+		case p.IsPaused():
+			state.State = constants.ParticipantStatePaused
+		case isPendingByParticipantID[p.ID]:
+			state.State = constants.ParticipantStateWaitingForAuthorization
+		case p.StartedAt != nil:
+			state.State = constants.ParticipantStateInProgress
+		default:
+			state.State = constants.ParticipantStateNotStarted
+		}
+		res = append(res, state)
+	}
+	return res
+}
+
+func (h *handler) MapParticipantSessionEntityListToPendingParticipantSessionDataList(pendingSessions []*participantsession.ParticipantSession, participants []*participant.Participant) []*PendingParticipantSessionData {
+	participantNames := map[uint]string{}
+	for _, p := range participants {
+		participantNames[p.ID] = p.Name
+	}
+
+	res := []*PendingParticipantSessionData{}
+	for _, pendingSession := range pendingSessions {
+		res = append(res, &PendingParticipantSessionData{
+			SessionSerial:   pendingSession.Serial,
+			ParticipantID:   pendingSession.ParticipantID,
+			ParticipantName: participantNames[pendingSession.ParticipantID],
+			RequestedAt:     pendingSession.CreatedAt,
+		})
+	}
+	return res
+}
+
+func (h *handler) MapParticipantSessionAuditEntityToParticipantSessionAuditData(audit *participantsession.ParticipantSessionAudit) *ParticipantSessionAuditData {
+	return &ParticipantSessionAuditData{
+		ID:                   audit.ID,
+		ParticipantSessionID: audit.ParticipantSessionID,
+		ParticipantID:        audit.ParticipantID,
+		Action:               audit.Action,
+		Reason:               audit.Reason,
+		CreatedAt:            audit.CreatedAt,
+	}
+}
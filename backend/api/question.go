@@ -41,14 +41,52 @@ type UpdateQuestionRequest struct {
 	Data string `json:"data"`
 }
 
+type ReorderQuestionsRequest struct {
+	QuestionIDs []uint `json:"question_ids" binding:"required"`
+}
+
 type ExamSessionQuestionData struct {
-	Question *QuestionData                `json:"question"`
-	Options  []*McqOptionWithoutPointData `json:"options"`
-	AnswerID uint                         `json:"answer"`
+	Question  *QuestionData                `json:"question"`
+	Options   []*McqOptionWithoutPointData `json:"options"`
+	AnswerID  uint                         `json:"answer"`
+	IsFlagged bool                         `json:"is_flagged"`
+}
+
+type FlagQuestionRequest struct {
+	IsFlagged bool   `json:"is_flagged"`
+	Sequence  *int64 `json:"sequence" binding:"required"` // a counter increasing per participant, the highest one wins
+}
+
+type FlagQuestionResponse struct {
+	Status   string `json:"status"`
+	Sequence int64  `json:"sequence"`
+}
+
+type ExamSessionSummaryData struct {
+	Questions             []*QuestionSummaryData `json:"questions"`
+	AnsweredQuestionIDs   []uint                 `json:"answered_question_ids"`
+	UnansweredQuestionIDs []uint                 `json:"unanswered_question_ids"`
+	FlaggedQuestionIDs    []uint                 `json:"flagged_question_ids"`
+}
+
+type QuestionSummaryData struct {
+	QuestionID   uint  `json:"question_id"`
+	IsAnswered   bool  `json:"is_answered"`
+	McqOptionID  uint  `json:"mcq_option_id"` // zero when the question is not answered
+	Sequence     int64 `json:"sequence"`      // the client continues its answer counter from the highest one
+	IsFlagged    bool  `json:"is_flagged"`
+	FlagSequence int64 `json:"flag_sequence"` // likewise for the flag counter
 }
 
 type SubmitAnswerRequest struct {
-	McqOptionID uint `json:"mcq_option_id" binding:"required"`
+	McqOptionID    uint   `json:"mcq_option_id" binding:"required"`
+	Sequence       *int64 `json:"sequence" binding:"required"` // a counter increasing per participant, the highest one wins
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+type SubmitAnswerResponse struct {
+	Status   string `json:"status"`
+	Sequence int64  `json:"sequence"`
 }
 
 type GetUploadQuestionBlobURLRequest struct {
@@ -60,6 +98,35 @@ type GetUploadQuestionBlobURLResponse struct {
 	PublicURL string `json:"public_url"`
 }
 
+type SyncAnswersRequest struct {
+	Answers []*SyncAnswerRequest `json:"answers" binding:"required,dive,required"`
+}
+
+type SyncAnswerRequest struct {
+	QuestionID     uint   `json:"question_id" binding:"required"`
+	McqOptionID    uint   `json:"mcq_option_id" binding:"required"`
+	Sequence       *int64 `json:"sequence" binding:"required"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+type SyncAnswersResponse struct {
+	Results []*SyncAnswerResultData `json:"results"`
+	Answers []*AnswerStateData      `json:"answers"` // the server state of every served question, for the client to replace its own with
+}
+
+type SyncAnswerResultData struct {
+	QuestionID  uint   `json:"question_id"`
+	McqOptionID uint   `json:"mcq_option_id"`
+	Sequence    int64  `json:"sequence"`
+	Status      string `json:"status"`
+}
+
+type AnswerStateData struct {
+	QuestionID  uint  `json:"question_id"`
+	McqOptionID uint  `json:"mcq_option_id"` // zero when the question is not answered
+	Sequence    int64 `json:"sequence"`
+}
+
 type GetExamSessionDetail struct {
 	QuestionsIDList []*QuestionDataIDOnly `json:"questions_id_list"`
 	StartTime       time.Time             `json:"start_time"`
@@ -112,6 +179,8 @@ func (h *handler) CreateQuestion(c *gin.Context) {
 		})
 	}
 
+	h.analyticsService.InvalidateExamStatistics(exam.ID)
+
 	res := h.MapQuestionEntityToQuestionData(svcRes)
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
@@ -241,16 +310,19 @@ func (h *handler) GetQuestionByID(c *gin.Context) {
 }
 
 func (h *handler) GetQuestionsIDByExamSerial(c *gin.Context) {
-	jwtClaims, err := lib.GetExamTokenJWTClaimsFromContext(c)
+	examSession, err := getExamSessionFromContext(c)
 	if err != nil {
-		log.Printf("[handler][question][GetQuestionsIDByExamSerial] error when get jwt: %s", err.Error())
+		log.Printf("[handler][question][GetQuestionsIDByExamSerial] error when get exam session: %s", err.Error())
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: lib.ErrUnknownError.Error(),
 		})
 		return
 	}
 
-	participant, err := h.participantService.GetParticipantByID(jwtClaims.ParticipantID)
+	participant := examSession.Participant
+	exam := examSession.Exam
+
+	questionIDs, err := h.questionPoolService.GetServedQuestionIDs(participant.ID, exam.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: err.Error(),
@@ -258,24 +330,34 @@ func (h *handler) GetQuestionsIDByExamSerial(c *gin.Context) {
 		return
 	}
 
-	if participant.StartedAt == nil {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrExamNotStarted.Error(),
-		})
-		return
+	res := GetExamSessionDetail{
+		QuestionsIDList: h.MapQuestionIDListToQuestionDataIDOnlyList(questionIDs),
+		StartTime:       participant.StartedAt.Add(participant.PausedDuration(time.Now())), // shifted by the pauses, so start time + duration is the deadline
+		Duration:        participant.TotalDurationMinutes(),
 	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
 
-	if participant.EndedAt != nil || participant.StartedAt.Add(time.Duration(participant.AllowedDurationMinutes)*time.Minute).Before(time.Now()) {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrExamAlreadySubmitted.Error(),
+func (h *handler) GetQuestionWithOptions(c *gin.Context) {
+	examSession, err := getExamSessionFromContext(c)
+	if err != nil {
+		log.Printf("[handler][question][GetQuestionWithOptions] error when get exam session: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: lib.ErrUnknownError.Error(),
 		})
 		return
 	}
 
-	examSerial := c.Param(constants.Serial)
-	exam, err := h.examService.GetExamBySerial(examSerial)
+	participant := examSession.Participant
+	exam := examSession.Exam
+
+	questionID, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+	question, err := h.questionService.GetQuestionByID(uint(questionID))
 	if err != nil {
-		if errors.Is(err, lib.ErrExamNotFound) {
+		if errors.Is(err, lib.ErrQuestionNotFound) {
 			c.JSON(http.StatusNotFound, lib.BaseResponse{
 				Message: err.Error(),
 			})
@@ -286,36 +368,45 @@ func (h *handler) GetQuestionsIDByExamSerial(c *gin.Context) {
 		})
 		return
 	}
-
-	if participant.ExamID != exam.ID {
-		c.JSON(http.StatusUnauthorized, lib.BaseResponse{
-			Message: lib.ErrUnauthorizedRequest.Error(),
+	if question.ExamID != exam.ID {
+		c.JSON(http.StatusNotFound, lib.BaseResponse{
+			Message: lib.ErrQuestionNotFound.Error(),
 		})
 		return
 	}
-
-	if !exam.IsOpen {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrExamNotFound.Error(),
+	isServed, err := h.questionPoolService.IsQuestionServedToParticipant(participant.ID, question.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
 		})
 		return
 	}
-
-	participantSession, err := h.participantSessionService.GetLatestAuthorizedParticipantSessionByParticipantID(participant.ID)
-	if err != nil {
+	if !isServed {
 		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
+			Message: lib.ErrQuestionNotFound.Error(),
 		})
 		return
 	}
-	if participantSession.Serial != jwtClaims.SessionSerial {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
+
+	mcqOptions, err := h.mcqOptionService.GetMcqOptionsByQuestionID(question.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
 		})
 		return
 	}
 
-	svcRes, err := h.questionService.GetQuestionsIDByExamID(exam.ID)
+	answer, err := h.submissionService.GetAnswer(participant.ID, question.ID)
+	if err != nil {
+		if !errors.Is(err, lib.ErrAnswerNotFound) {
+			c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	isFlagged, err := h.submissionService.IsFlagged(participant.ID, question.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: err.Error(),
@@ -323,10 +414,15 @@ func (h *handler) GetQuestionsIDByExamSerial(c *gin.Context) {
 		return
 	}
 
-	res := GetExamSessionDetail{
-		QuestionsIDList: h.MapQuestionEntityListToQuestionDataIDOnlyList(svcRes),
-		StartTime:       *participant.StartedAt,
-		Duration:        participant.AllowedDurationMinutes,
+	answerID := uint(0)
+	if answer != nil {
+		answerID = answer.McqOptionID
+	}
+	res := ExamSessionQuestionData{
+		Question:  h.MapQuestionEntityToQuestionData(question),
+		Options:   h.MapMcqOptionEntityListToMcqOptionWithoutPointDataList(mcqOptions),
+		AnswerID:  answerID,
+		IsFlagged: isFlagged,
 	}
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
@@ -334,19 +430,31 @@ func (h *handler) GetQuestionsIDByExamSerial(c *gin.Context) {
 	})
 }
 
-func (h *handler) GetQuestionWithOptions(c *gin.Context) {
-	jwtClaims, err := lib.GetExamTokenJWTClaimsFromContext(c)
+func (h *handler) SubmitAnswer(c *gin.Context) {
+	examSession, err := getExamSessionFromContext(c)
 	if err != nil {
-		log.Printf("[handler][question][GetQuestionsIDByExamSerial] error when get jwt: %s", err.Error())
+		log.Printf("[handler][question][SubmitAnswer] error when get exam session: %s", err.Error())
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: lib.ErrUnknownError.Error(),
 		})
 		return
 	}
 
-	participant, err := h.participantService.GetParticipantByID(jwtClaims.ParticipantID)
+	var req SubmitAnswerRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	participant := examSession.Participant
+	exam := examSession.Exam
+
+	questionID, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+	question, err := h.questionService.GetQuestionByID(uint(questionID))
 	if err != nil {
-		if errors.Is(err, lib.ErrParticipantNotFound) {
+		if errors.Is(err, lib.ErrQuestionNotFound) {
 			c.JSON(http.StatusNotFound, lib.BaseResponse{
 				Message: err.Error(),
 			})
@@ -357,64 +465,93 @@ func (h *handler) GetQuestionWithOptions(c *gin.Context) {
 		})
 		return
 	}
-
-	if participant.StartedAt == nil {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrExamNotStarted.Error(),
+	if question.ExamID != exam.ID {
+		c.JSON(http.StatusNotFound, lib.BaseResponse{
+			Message: lib.ErrQuestionNotFound.Error(),
 		})
 		return
 	}
-
-	if participant.EndedAt != nil || participant.StartedAt.Add(time.Duration(participant.AllowedDurationMinutes)*time.Minute).Before(time.Now()) {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrExamAlreadySubmitted.Error(),
+	isServed, err := h.questionPoolService.IsQuestionServedToParticipant(participant.ID, question.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	if !isServed {
+		c.JSON(http.StatusNotFound, lib.BaseResponse{
+			Message: lib.ErrQuestionNotFound.Error(),
 		})
 		return
 	}
 
-	examSerial := c.Param(constants.Serial)
-	exam, err := h.examService.GetExamBySerial(examSerial)
+	mcqOption, err := h.mcqOptionService.GetMcqOptionByID(req.McqOptionID)
 	if err != nil {
-		if errors.Is(err, lib.ErrExamNotFound) {
-			c.JSON(http.StatusNotFound, lib.BaseResponse{
-				Message: err.Error(),
-			})
-			return
-		}
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: err.Error(),
 		})
 		return
 	}
-
-	if participant.ExamID != exam.ID {
-		c.JSON(http.StatusUnauthorized, lib.BaseResponse{
-			Message: lib.ErrUnauthorizedRequest.Error(),
+	if mcqOption.QuestionID != question.ID {
+		c.JSON(http.StatusNotFound, lib.BaseResponse{
+			Message: lib.ErrMcqOptionNotFound.Error(),
 		})
 		return
 	}
 
-	if !exam.IsOpen {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrExamNotFound.Error(),
+	now := time.Now()
+	sequence := *req.Sequence
+	status, err := h.submissionService.Answer(&submission.ExamSessionSubmissionCacheObject{
+		ParticipantID:  participant.ID,
+		QuestionID:     question.ID,
+		McqOptionID:    mcqOption.ID,
+		SessionSerial:  examSession.ParticipantSession.Serial,
+		Sequence:       sequence,
+		IdempotencyKey: req.IdempotencyKey,
+		Timestamp:      now.Truncate(time.Second),
+	})
+	if err != nil {
+		if errors.Is(err, lib.ErrExamAlreadySubmitted) {
+			c.JSON(http.StatusBadRequest, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
 		})
 		return
 	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data: &SubmitAnswerResponse{
+			Status:   status,
+			Sequence: sequence,
+		},
+	})
+}
 
-	participantSession, err := h.participantSessionService.GetLatestAuthorizedParticipantSessionByParticipantID(participant.ID)
+func (h *handler) FlagQuestion(c *gin.Context) {
+	examSession, err := getExamSessionFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
+		log.Printf("[handler][question][FlagQuestion] error when get exam session: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: lib.ErrUnknownError.Error(),
 		})
 		return
 	}
-	if participantSession.Serial != jwtClaims.SessionSerial {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
+
+	var req FlagQuestionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
 		})
 		return
 	}
 
+	participant := examSession.Participant
+	exam := examSession.Exam
+
 	questionID, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
 	question, err := h.questionService.GetQuestionByID(uint(questionID))
 	if err != nil {
@@ -435,182 +572,242 @@ func (h *handler) GetQuestionWithOptions(c *gin.Context) {
 		})
 		return
 	}
-
-	mcqOptions, err := h.mcqOptionService.GetMcqOptionsByQuestionID(question.ID)
+	isServed, err := h.questionPoolService.IsQuestionServedToParticipant(participant.ID, question.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: err.Error(),
 		})
 		return
 	}
-
-	answer, err := h.submissionService.GetAnswer(participant.ID, question.ID)
-	if err != nil {
-		if !errors.Is(err, lib.ErrAnswerNotFound) {
-			c.JSON(http.StatusInternalServerError, lib.BaseResponse{
-				Message: err.Error(),
-			})
-			return
-		}
+	if !isServed {
+		c.JSON(http.StatusNotFound, lib.BaseResponse{
+			Message: lib.ErrQuestionNotFound.Error(),
+		})
+		return
 	}
 
-	answerID := uint(0)
-	if answer != nil {
-		answerID = answer.McqOptionID
-	}
-	res := ExamSessionQuestionData{
-		Question: h.MapQuestionEntityToQuestionData(question),
-		Options:  h.MapMcqOptionEntityListToMcqOptionWithoutPointDataList(mcqOptions),
-		AnswerID: answerID,
+	status, err := h.submissionService.Flag(&submission.ExamSessionFlagCacheObject{
+		ParticipantID: participant.ID,
+		QuestionID:    question.ID,
+		IsFlagged:     req.IsFlagged,
+		Sequence:      *req.Sequence,
+		Timestamp:     time.Now().Truncate(time.Second),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
 	}
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
-		Data:    res,
+		Data: &FlagQuestionResponse{
+			Status:   status,
+			Sequence: *req.Sequence,
+		},
 	})
 }
 
-func (h *handler) SubmitAnswer(c *gin.Context) {
-	jwtClaims, err := lib.GetExamTokenJWTClaimsFromContext(c)
+// GetExamSessionSummary lists the served questions by their state, for the navigation grid.
+func (h *handler) GetExamSessionSummary(c *gin.Context) {
+	examSession, err := getExamSessionFromContext(c)
 	if err != nil {
-		log.Printf("[handler][question][SubmitAnswer] error when get jwt: %s", err.Error())
+		log.Printf("[handler][question][GetExamSessionSummary] error when get exam session: %s", err.Error())
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: lib.ErrUnknownError.Error(),
 		})
 		return
 	}
 
-	var req SubmitAnswerRequest
-	if err := c.ShouldBind(&req); err != nil {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrFailedToParseRequest.Error(),
-		})
-		return
-	}
+	participant := examSession.Participant
+	exam := examSession.Exam
 
-	participant, err := h.participantService.GetParticipantByID(jwtClaims.ParticipantID)
+	questionIDs, err := h.questionPoolService.GetServedQuestionIDs(participant.ID, exam.ID)
 	if err != nil {
-		if errors.Is(err, lib.ErrParticipantNotFound) {
-			c.JSON(http.StatusNotFound, lib.BaseResponse{
-				Message: err.Error(),
-			})
-			return
-		}
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: err.Error(),
 		})
 		return
 	}
 
-	if participant.StartedAt == nil {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrExamNotStarted.Error(),
+	answers, err := h.submissionService.GetAnswers(participant.ID, questionIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
 		})
 		return
 	}
 
-	if participant.EndedAt != nil || participant.StartedAt.Add(time.Duration(participant.AllowedDurationMinutes)*time.Minute).Before(time.Now()) {
-		c.JSON(http.StatusBadRequest, lib.BaseResponse{
-			Message: lib.ErrExamAlreadySubmitted.Error(),
+	flags, err := h.submissionService.GetFlags(participant.ID, questionIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
 		})
 		return
 	}
 
-	examSerial := c.Param(constants.Serial)
-	exam, err := h.examService.GetExamBySerial(examSerial)
+	res := h.MapAnswersToExamSessionSummaryData(questionIDs, answers, flags)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) SyncAnswers(c *gin.Context) {
+	examSession, err := getExamSessionFromContext(c)
 	if err != nil {
-		if errors.Is(err, lib.ErrExamNotFound) {
-			c.JSON(http.StatusNotFound, lib.BaseResponse{
-				Message: err.Error(),
-			})
-			return
-		}
+		log.Printf("[handler][question][SyncAnswers] error when get exam session: %s", err.Error())
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
-			Message: err.Error(),
+			Message: lib.ErrUnknownError.Error(),
 		})
 		return
 	}
 
-	if participant.ExamID != exam.ID {
-		c.JSON(http.StatusUnauthorized, lib.BaseResponse{
-			Message: lib.ErrUnauthorizedRequest.Error(),
+	var req SyncAnswersRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
 		})
 		return
 	}
-
-	if !exam.IsOpen {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrExamNotFound.Error(),
+	if len(req.Answers) > constants.MaxSyncAnswersBatchSize {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrTooManyAnswers.Error(),
 		})
 		return
 	}
 
-	participantSession, err := h.participantSessionService.GetLatestAuthorizedParticipantSessionByParticipantID(participant.ID)
+	participant := examSession.Participant
+	exam := examSession.Exam
+
+	questionIDs, err := h.questionPoolService.GetServedQuestionIDs(participant.ID, exam.ID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
 		})
 		return
 	}
-	if participantSession.Serial != jwtClaims.SessionSerial {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrSessionNotFound.Error(),
-		})
-		return
+	isServed := map[uint]bool{}
+	for _, questionID := range questionIDs {
+		isServed[questionID] = true
 	}
 
-	questionID, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
-	question, err := h.questionService.GetQuestionByID(uint(questionID))
-	if err != nil {
-		if errors.Is(err, lib.ErrQuestionNotFound) {
-			c.JSON(http.StatusNotFound, lib.BaseResponse{
+	// the options are loaded once per question, so the whole batch is validated in one pass
+	mcqOptionQuestionIDs := map[uint]uint{}
+	isLoaded := map[uint]bool{}
+	for _, answer := range req.Answers {
+		if !isServed[answer.QuestionID] || isLoaded[answer.QuestionID] {
+			continue
+		}
+		isLoaded[answer.QuestionID] = true
+		mcqOptions, err := h.mcqOptionService.GetMcqOptionsByQuestionID(answer.QuestionID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 				Message: err.Error(),
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
-			Message: err.Error(),
-		})
-		return
+		for _, mcqOption := range mcqOptions {
+			mcqOptionQuestionIDs[mcqOption.ID] = mcqOption.QuestionID
+		}
 	}
-	if question.ExamID != exam.ID {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrQuestionNotFound.Error(),
+
+	now := time.Now()
+	results := []*SyncAnswerResultData{}
+	for _, answer := range req.Answers {
+		sequence := *answer.Sequence
+		result := &SyncAnswerResultData{
+			QuestionID:  answer.QuestionID,
+			McqOptionID: answer.McqOptionID,
+			Sequence:    sequence,
+			Status:      submission.AnswerStatusInvalid,
+		}
+		results = append(results, result)
+		if !isServed[answer.QuestionID] || mcqOptionQuestionIDs[answer.McqOptionID] != answer.QuestionID {
+			continue
+		}
+
+		status, err := h.submissionService.Answer(&submission.ExamSessionSubmissionCacheObject{
+			ParticipantID:  participant.ID,
+			QuestionID:     answer.QuestionID,
+			McqOptionID:    answer.McqOptionID,
+			SessionSerial:  examSession.ParticipantSession.Serial,
+			Sequence:       sequence,
+			IdempotencyKey: answer.IdempotencyKey,
+			Timestamp:      now.Truncate(time.Second),
 		})
-		return
+		if err != nil {
+			if errors.Is(err, lib.ErrExamAlreadySubmitted) {
+				c.JSON(http.StatusBadRequest, lib.BaseResponse{
+					Message: err.Error(),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		result.Status = status
 	}
 
-	mcqOption, err := h.mcqOptionService.GetMcqOptionByID(req.McqOptionID)
+	submissions, err := h.submissionService.GetAnswers(participant.ID, questionIDs)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: err.Error(),
 		})
 		return
 	}
-	if mcqOption.QuestionID != question.ID {
-		c.JSON(http.StatusNotFound, lib.BaseResponse{
-			Message: lib.ErrMcqOptionNotFound.Error(),
+
+	answers := []*AnswerStateData{}
+	for _, questionID := range questionIDs {
+		state := &AnswerStateData{
+			QuestionID: questionID,
+		}
+		if answer, ok := submissions[questionID]; ok {
+			state.McqOptionID = answer.McqOptionID
+			state.Sequence = answer.Sequence
+		}
+		answers = append(answers, state)
+	}
+
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data: &SyncAnswersResponse{
+			Results: results,
+			Answers: answers,
+		},
+	})
+}
+
+func (h *handler) UpdateQuestion(c *gin.Context) {
+	var req UpdateQuestionRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
 		})
 		return
 	}
 
-	err = h.submissionService.Answer(&submission.ExamSessionSubmissionCacheObject{
-		ParticipantID: participant.ID,
-		QuestionID:    question.ID,
-		McqOptionID:   mcqOption.ID,
-		Timestamp:     time.Now().Truncate(time.Second),
-	})
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+	req.ID = uint(id)
+	svcReq := h.MapUpdateQuestionRequestToQuestionEntity(&req)
+
+	err := h.questionService.UpdateQuestion(svcReq)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: err.Error(),
 		})
+		return
 	}
 	c.JSON(http.StatusOK, lib.BaseResponse{
 		Message: constants.Success,
 	})
 }
 
-func (h *handler) UpdateQuestion(c *gin.Context) {
-	var req UpdateQuestionRequest
+func (h *handler) ReorderQuestions(c *gin.Context) {
+	var req ReorderQuestionsRequest
 
 	if err := c.ShouldBind(&req); err != nil {
 		c.JSON(http.StatusBadRequest, lib.BaseResponse{
@@ -619,12 +816,28 @@ func (h *handler) UpdateQuestion(c *gin.Context) {
 		return
 	}
 
-	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
-	req.ID = uint(id)
-	svcReq := h.MapUpdateQuestionRequestToQuestionEntity(&req)
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
 
-	err := h.questionService.UpdateQuestion(svcReq)
+	err = h.questionService.ReorderQuestions(exam.ID, req.QuestionIDs)
 	if err != nil {
+		if errors.Is(err, lib.ErrInvalidQuestionOrder) {
+			c.JSON(http.StatusBadRequest, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
 			Message: err.Error(),
 		})
@@ -638,6 +851,8 @@ func (h *handler) UpdateQuestion(c *gin.Context) {
 func (h *handler) DeleteQuestionBySerial(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
 
+	h.InvalidateExamStatisticsByQuestionID(uint(id))
+
 	err := h.questionService.DeleteQuestionByID(uint(id))
 	if err != nil {
 		if errors.Is(err, lib.ErrQuestionNotFound) {
@@ -713,3 +928,44 @@ func (h *handler) MapGetUploadURLResponseEntityToGetUploadQuestionBlobURLRespons
 		PublicURL: svcRes.PublicURL,
 	}
 }
+
+func (h *handler) MapQuestionIDListToQuestionDataIDOnlyList(questionIDs []uint) []*QuestionDataIDOnly {
+	res := []*QuestionDataIDOnly{}
+	for _, questionID := range questionIDs {
+		res = append(res, &QuestionDataIDOnly{
+			ID: questionID,
+		})
+	}
+	return res
+}
+
+func (h *handler) MapAnswersToExamSessionSummaryData(questionIDs []uint, answers map[uint]*submission.Submission, flags map[uint]*submission.SubmissionFlag) *ExamSessionSummaryData {
+	res := &ExamSessionSummaryData{
+		Questions:             []*QuestionSummaryData{},
+		AnsweredQuestionIDs:   []uint{},
+		UnansweredQuestionIDs: []uint{},
+		FlaggedQuestionIDs:    []uint{},
+	}
+	for _, questionID := range questionIDs {
+		data := &QuestionSummaryData{
+			QuestionID: questionID,
+		}
+		if flag, ok := flags[questionID]; ok {
+			data.IsFlagged = flag.IsFlagged
+			data.FlagSequence = flag.Sequence
+		}
+		if answer, ok := answers[questionID]; ok {
+			data.IsAnswered = true
+			data.McqOptionID = answer.McqOptionID
+			data.Sequence = answer.Sequence
+			res.AnsweredQuestionIDs = append(res.AnsweredQuestionIDs, questionID)
+		} else {
+			res.UnansweredQuestionIDs = append(res.UnansweredQuestionIDs, questionID)
+		}
+		if data.IsFlagged {
+			res.FlaggedQuestionIDs = append(res.FlaggedQuestionIDs, questionID)
+		}
+		res.Questions = append(res.Questions, data)
+	}
+	return res
+}
@@ -0,0 +1,332 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/questionbank"
+	"gorm.io/gorm"
+)
+
+/***
+	entity
+***/
+
+type BankMcqOptionRequest struct {
+	Description string `json:"description"`
+	Point       int    `json:"point"`
+}
+
+type CreateBankQuestionRequest struct {
+	Subject    string                  `json:"subject"`
+	Grade      string                  `json:"grade"`
+	Topic      string                  `json:"topic"`
+	Difficulty string                  `json:"difficulty"`
+	Data       string                  `json:"data"`
+	Options    []*BankMcqOptionRequest `json:"options"`
+}
+
+type UpdateBankQuestionRequest struct {
+	ID         uint                    `json:"-"`
+	Subject    string                  `json:"subject"`
+	Grade      string                  `json:"grade"`
+	Topic      string                  `json:"topic"`
+	Difficulty string                  `json:"difficulty"`
+	Data       string                  `json:"data"`
+	Options    []*BankMcqOptionRequest `json:"options"`
+}
+
+type BankMcqOptionData struct {
+	ID          uint   `json:"id"`
+	Description string `json:"description"`
+	Point       int    `json:"point"`
+}
+
+type BankQuestionData struct {
+	ID         uint                 `json:"id"`
+	Subject    string               `json:"subject"`
+	Grade      string               `json:"grade"`
+	Topic      string               `json:"topic"`
+	Difficulty string               `json:"difficulty"`
+	Data       string               `json:"data"`
+	Options    []*BankMcqOptionData `json:"options,omitempty"`
+}
+
+type SnapshotBankQuestionsRequest struct {
+	BankQuestionIDs []uint `json:"bank_question_ids" binding:"required"`
+}
+
+/***
+	handler
+***/
+
+func (h *handler) CreateBankQuestion(c *gin.Context) {
+	var req CreateBankQuestionRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	svcReq := h.MapCreateBankQuestionRequestToBankQuestionEntity(&req)
+	svcReqOptions := h.MapBankMcqOptionRequestListToBankMcqOptionEntityList(req.Options)
+
+	svcRes, err := h.questionBankService.CreateBankQuestion(svcReq, svcReqOptions)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapBankQuestionEntityToBankQuestionData(svcRes, svcReqOptions)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) GetBankQuestions(c *gin.Context) {
+	var filter questionbank.GetBankQuestionsFilter
+
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	pagination, err := lib.GetQueryPaginationFromContext(c)
+	if err != nil {
+		log.Printf("[handler][questionbank][GetBankQuestions] get query pagination error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.questionBankService.GetBankQuestions(pagination, &filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapBankQuestionEntityListToBankQuestionDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) GetBankQuestionByID(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	svcRes, err := h.questionBankService.GetBankQuestionByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrBankQuestionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	bankMcqOptions, err := h.questionBankService.GetBankMcqOptionsByBankQuestionID(svcRes.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapBankQuestionEntityToBankQuestionData(svcRes, bankMcqOptions)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) UpdateBankQuestion(c *gin.Context) {
+	var req UpdateBankQuestionRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+	req.ID = uint(id)
+	svcReq := h.MapUpdateBankQuestionRequestToBankQuestionEntity(&req)
+	svcReqOptions := h.MapBankMcqOptionRequestListToBankMcqOptionEntityList(req.Options)
+
+	err := h.questionBankService.UpdateBankQuestion(svcReq, svcReqOptions)
+	if err != nil {
+		if errors.Is(err, lib.ErrBankQuestionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+func (h *handler) DeleteBankQuestionByID(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	err := h.questionBankService.DeleteBankQuestionByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrBankQuestionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+func (h *handler) SnapshotBankQuestionsToExam(c *gin.Context) {
+	var req SnapshotBankQuestionsRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.questionBankService.SnapshotBankQuestionsToExam(exam.ID, req.BankQuestionIDs)
+	if err != nil {
+		if errors.Is(err, lib.ErrBankQuestionNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.analyticsService.InvalidateExamStatistics(exam.ID)
+
+	res := h.MapQuestionEntityListToQuestionDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+/***
+	mapping
+***/
+
+func (h *handler) MapCreateBankQuestionRequestToBankQuestionEntity(req *CreateBankQuestionRequest) *questionbank.BankQuestion {
+	return &questionbank.BankQuestion{
+		Subject:    req.Subject,
+		Grade:      req.Grade,
+		Topic:      req.Topic,
+		Difficulty: req.Difficulty,
+		Data:       req.Data,
+	}
+}
+
+func (h *handler) MapUpdateBankQuestionRequestToBankQuestionEntity(req *UpdateBankQuestionRequest) *questionbank.BankQuestion {
+	return &questionbank.BankQuestion{
+		BaseModel: lib.BaseModel{
+			Model: gorm.Model{
+				ID: req.ID,
+			},
+		},
+		Subject:    req.Subject,
+		Grade:      req.Grade,
+		Topic:      req.Topic,
+		Difficulty: req.Difficulty,
+		Data:       req.Data,
+	}
+}
+
+func (h *handler) MapBankMcqOptionRequestListToBankMcqOptionEntityList(req []*BankMcqOptionRequest) []*questionbank.BankMcqOption {
+	res := []*questionbank.BankMcqOption{}
+	for _, obj := range req {
+		res = append(res, &questionbank.BankMcqOption{
+			Description: obj.Description,
+			Point:       obj.Point,
+		})
+	}
+	return res
+}
+
+func (h *handler) MapBankMcqOptionEntityToBankMcqOptionData(svcRes *questionbank.BankMcqOption) *BankMcqOptionData {
+	return &BankMcqOptionData{
+		ID:          svcRes.ID,
+		Description: svcRes.Description,
+		Point:       svcRes.Point,
+	}
+}
+
+func (h *handler) MapBankQuestionEntityToBankQuestionData(svcRes *questionbank.BankQuestion, bankMcqOptions []*questionbank.BankMcqOption) *BankQuestionData {
+	res := &BankQuestionData{
+		ID:         svcRes.ID,
+		Subject:    svcRes.Subject,
+		Grade:      svcRes.Grade,
+		Topic:      svcRes.Topic,
+		Difficulty: svcRes.Difficulty,
+		Data:       svcRes.Data,
+	}
+	for _, obj := range bankMcqOptions {
+		res.Options = append(res.Options, h.MapBankMcqOptionEntityToBankMcqOptionData(obj))
+	}
+	return res
+}
+
+func (h *handler) MapBankQuestionEntityListToBankQuestionDataList(svcRes []*questionbank.BankQuestion) []*BankQuestionData {
+	res := []*BankQuestionData{}
+	for _, obj := range svcRes {
+		res = append(res, h.MapBankQuestionEntityToBankQuestionData(obj, nil))
+	}
+	return res
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/questionpool"
+)
+
+/***
+	entity
+***/
+
+type CreateExamQuestionPoolRequest struct {
+	ExamSerial string `json:"exam_serial" binding:"required"`
+	ExamID     uint   `json:"-"`
+	Subject    string `json:"subject"`
+	Grade      string `json:"grade"`
+	Topic      string `json:"topic"`
+	Difficulty string `json:"difficulty"`
+	DrawCount  uint   `json:"draw_count" binding:"required"`
+}
+
+type ExamQuestionPoolData struct {
+	ID         uint   `json:"id"`
+	Subject    string `json:"subject"`
+	Grade      string `json:"grade"`
+	Topic      string `json:"topic"`
+	Difficulty string `json:"difficulty"`
+	DrawCount  uint   `json:"draw_count"`
+}
+
+type ParticipantQuestionData struct {
+	QuestionID  uint `json:"question_id"`
+	OrderNumber uint `json:"order_number"`
+}
+
+/***
+	handler
+***/
+
+func (h *handler) CreateExamQuestionPool(c *gin.Context) {
+	var req CreateExamQuestionPoolRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	exam, err := h.examService.GetExamBySerial(req.ExamSerial)
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	req.ExamID = exam.ID
+	svcReq := h.MapCreateExamQuestionPoolRequestToExamQuestionPoolEntity(&req)
+
+	svcRes, err := h.questionPoolService.CreateExamQuestionPool(svcReq)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapExamQuestionPoolEntityToExamQuestionPoolData(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) GetExamQuestionPoolsByExamSerial(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.questionPoolService.GetExamQuestionPoolsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapExamQuestionPoolEntityListToExamQuestionPoolDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) DeleteExamQuestionPoolByID(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	err := h.questionPoolService.DeleteExamQuestionPoolByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamQuestionPoolNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+	})
+}
+
+func (h *handler) GetParticipantQuestions(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	svcRes, err := h.questionPoolService.GetParticipantQuestionsByParticipantID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapParticipantQuestionEntityListToParticipantQuestionDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+/***
+	mapping
+***/
+
+func (h *handler) MapCreateExamQuestionPoolRequestToExamQuestionPoolEntity(req *CreateExamQuestionPoolRequest) *questionpool.ExamQuestionPool {
+	return &questionpool.ExamQuestionPool{
+		ExamID:     req.ExamID,
+		Subject:    req.Subject,
+		Grade:      req.Grade,
+		Topic:      req.Topic,
+		Difficulty: req.Difficulty,
+		DrawCount:  req.DrawCount,
+	}
+}
+
+func (h *handler) MapExamQuestionPoolEntityToExamQuestionPoolData(svcRes *questionpool.ExamQuestionPool) *ExamQuestionPoolData {
+	return &ExamQuestionPoolData{
+		ID:         svcRes.ID,
+		Subject:    svcRes.Subject,
+		Grade:      svcRes.Grade,
+		Topic:      svcRes.Topic,
+		Difficulty: svcRes.Difficulty,
+		DrawCount:  svcRes.DrawCount,
+	}
+}
+
+func (h *handler) MapExamQuestionPoolEntityListToExamQuestionPoolDataList(svcRes []*questionpool.ExamQuestionPool) []*ExamQuestionPoolData {
+	res := []*ExamQuestionPoolData{}
+	for _, obj := range svcRes {
+		res = append(res, h.MapExamQuestionPoolEntityToExamQuestionPoolData(obj))
+	}
+	return res
+}
+
+func (h *handler) MapParticipantQuestionEntityListToParticipantQuestionDataList(svcRes []*questionpool.ParticipantQuestion) []*ParticipantQuestionData {
+	res := []*ParticipantQuestionData{}
+	for _, obj := range svcRes {
+		res = append(res, &ParticipantQuestionData{
+			QuestionID:  obj.QuestionID,
+			OrderNumber: obj.OrderNumber,
+		})
+	}
+	return res
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/receipt"
+)
+
+/***
+	entity
+***/
+
+type SubmissionReceiptData struct {
+	ParticipantID uint      `json:"participant_id" binding:"required"`
+	ExamID        uint      `json:"exam_id" binding:"required"`
+	AnswerCount   int       `json:"answer_count"`
+	AnswerHash    string    `json:"answer_hash" binding:"required"`
+	SubmittedAt   time.Time `json:"submitted_at" binding:"required"`
+	Signature     string    `json:"signature" binding:"required"`
+}
+
+type ReceiptSignatureVerificationData struct {
+	IsSignatureValid bool `json:"is_signature_valid"`
+}
+
+type ReceiptVerificationData struct {
+	IsSignatureValid  bool `json:"is_signature_valid"`
+	IsAnswerUnchanged bool `json:"is_answer_unchanged"`
+}
+
+/***
+	handler
+***/
+
+// VerifyReceipt is public, so a student or a teacher holding a receipt can check it without logging in.
+// It only tells whether the receipt is genuine, the stored answers are compared by VerifyReceiptAnswers for admins.
+func (h *handler) VerifyReceipt(c *gin.Context) {
+	var req SubmissionReceiptData
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data: &ReceiptSignatureVerificationData{
+			IsSignatureValid: h.receiptService.VerifyReceiptSignature(h.MapSubmissionReceiptDataToSubmissionReceiptEntity(&req)),
+		},
+	})
+}
+
+// VerifyReceiptAnswers checks a receipt and whether the participant's stored answers still match it.
+func (h *handler) VerifyReceiptAnswers(c *gin.Context) {
+	var req SubmissionReceiptData
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, lib.BaseResponse{
+			Message: lib.ErrFailedToParseRequest.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.receiptService.VerifyReceipt(h.MapSubmissionReceiptDataToSubmissionReceiptEntity(&req))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data: &ReceiptVerificationData{
+			IsSignatureValid:  svcRes.IsSignatureValid,
+			IsAnswerUnchanged: svcRes.IsAnswerUnchanged,
+		},
+	})
+}
+
+// GetParticipantReceipt returns the receipt of a submitted participant, and issues it when the submission could not.
+func (h *handler) GetParticipantReceipt(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param(constants.ID), 10, 64)
+
+	participant, err := h.participantService.GetParticipantByID(uint(id))
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+	h.issueParticipantReceipt(c, participant)
+}
+
+// GetExamSessionReceipt lets the participant fetch their own receipt when the submission could not return it.
+func (h *handler) GetExamSessionReceipt(c *gin.Context) {
+	examSession, err := getExamSessionFromContext(c)
+	if err != nil {
+		log.Printf("[handler][receipt][GetExamSessionReceipt] error when get exam session: %s", err.Error())
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: lib.ErrUnknownError.Error(),
+		})
+		return
+	}
+	h.issueParticipantReceipt(c, examSession.Participant)
+}
+
+// issueParticipantReceipt responds with the receipt of the participant, which is only issued once the participant is ended.
+func (h *handler) issueParticipantReceipt(c *gin.Context, participant *participant.Participant) {
+	if participant.EndedAt == nil {
+		c.JSON(http.StatusNotFound, lib.BaseResponse{
+			Message: lib.ErrReceiptNotFound.Error(),
+		})
+		return
+	}
+
+	questionIDs, err := h.questionPoolService.GetServedQuestionIDs(participant.ID, participant.ExamID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.receiptService.IssueReceipt(participant, questionIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapSubmissionReceiptEntityToSubmissionReceiptData(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+/***
+	mapping
+***/
+
+func (h *handler) MapSubmissionReceiptEntityToSubmissionReceiptData(svcRes *receipt.SubmissionReceipt) *SubmissionReceiptData {
+	return &SubmissionReceiptData{
+		ParticipantID: svcRes.ParticipantID,
+		ExamID:        svcRes.ExamID,
+		AnswerCount:   svcRes.AnswerCount,
+		AnswerHash:    svcRes.AnswerHash,
+		SubmittedAt:   svcRes.SubmittedAt,
+		Signature:     svcRes.Signature,
+	}
+}
+
+func (h *handler) MapSubmissionReceiptDataToSubmissionReceiptEntity(req *SubmissionReceiptData) *receipt.SubmissionReceipt {
+	return &receipt.SubmissionReceipt{
+		ParticipantID: req.ParticipantID,
+		ExamID:        req.ExamID,
+		AnswerCount:   req.AnswerCount,
+		AnswerHash:    req.AnswerHash,
+		SubmittedAt:   req.SubmittedAt,
+		Signature:     req.Signature,
+	}
+}
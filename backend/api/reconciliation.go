@@ -0,0 +1,145 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/reconciliation"
+)
+
+/***
+	entity
+***/
+
+type SubmissionReconciliationData struct {
+	ID           uint                      `json:"id"`
+	ScannedCount int                       `json:"scanned_count"`
+	MissingCount int                       `json:"missing_count"`
+	StaleCount   int                       `json:"stale_count"`
+	FailedCount  int                       `json:"failed_count"`
+	FinishedAt   time.Time                 `json:"finished_at"`
+	Items        []*ReconciliationItemData `json:"items,omitempty"`
+}
+
+type ReconciliationItemData struct {
+	ParticipantID uint   `json:"participant_id"`
+	QuestionID    uint   `json:"question_id"`
+	Status        string `json:"status"`
+}
+
+/***
+	handler
+***/
+
+func (h *handler) ReconcileExamSubmissions(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.reconciliationService.ReconcileExam(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapSubmissionReconciliationEntityToSubmissionReconciliationData(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+func (h *handler) GetSubmissionReconciliationsByExamSerial(c *gin.Context) {
+	exam, err := h.examService.GetExamBySerial(c.Param(constants.Serial))
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			c.JSON(http.StatusNotFound, lib.BaseResponse{
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	svcRes, err := h.reconciliationService.GetSubmissionReconciliationsByExamID(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	res := h.MapSubmissionReconciliationEntityListToSubmissionReconciliationDataList(svcRes)
+	c.JSON(http.StatusOK, lib.BaseResponse{
+		Message: constants.Success,
+		Data:    res,
+	})
+}
+
+// ensureExamReconciled writes the error response and returns false when the exam still has answers which are not in the database, since grading them would be wrong.
+func (h *handler) ensureExamReconciled(c *gin.Context, examID uint) bool {
+	isReconciled, err := h.reconciliationService.IsExamReconciled(examID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, lib.BaseResponse{
+			Message: err.Error(),
+		})
+		return false
+	}
+	if !isReconciled {
+		c.JSON(http.StatusConflict, lib.BaseResponse{
+			Message: lib.ErrExamNotReconciled.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+/***
+	mapping
+***/
+
+func (h *handler) MapSubmissionReconciliationEntityToSubmissionReconciliationData(svcRes *reconciliation.SubmissionReconciliation) *SubmissionReconciliationData {
+	res := &SubmissionReconciliationData{
+		ID:           svcRes.ID,
+		ScannedCount: svcRes.ScannedCount,
+		MissingCount: svcRes.MissingCount,
+		StaleCount:   svcRes.StaleCount,
+		FailedCount:  svcRes.FailedCount,
+		FinishedAt:   svcRes.FinishedAt,
+	}
+	for _, item := range svcRes.Items {
+		res.Items = append(res.Items, &ReconciliationItemData{
+			ParticipantID: item.ParticipantID,
+			QuestionID:    item.QuestionID,
+			Status:        item.Status,
+		})
+	}
+	return res
+}
+
+func (h *handler) MapSubmissionReconciliationEntityListToSubmissionReconciliationDataList(svcRes []*reconciliation.SubmissionReconciliation) []*SubmissionReconciliationData {
+	res := []*SubmissionReconciliationData{}
+	for _, obj := range svcRes {
+		res = append(res, h.MapSubmissionReconciliationEntityToSubmissionReconciliationData(obj))
+	}
+	return res
+}
@@ -38,6 +38,8 @@ func (s *service) GetDSN() string {
 func (s *service) InitDB() *gorm.DB {
 	db, err := gorm.Open(mysql.Open(s.GetDSN()), &gorm.Config{
 		Logger: logger.Default.LogMode(s.cfg.GORMLogLevel),
+		// duplicate keys surface as gorm.ErrDuplicatedKey, for the inserts racing on a unique index
+		TranslateError: true,
 	})
 	if err != nil {
 		panic(err)
@@ -0,0 +1,40 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+// Queue is the subset of a message queue the services and workers rely on, so the backend can be swapped in config.
+type Queue interface {
+	Publish(payload ...string) error
+	StartConsuming(prefetchLimit int64, pollDuration time.Duration) error
+	AddConsumer(tag string, consumer Consumer) (string, error)
+	ReturnRejected(max int64) (int64, error)
+}
+
+type Consumer interface {
+	Consume(delivery Delivery)
+}
+
+type Delivery interface {
+	Payload() string
+	Ack() error
+	Reject() error
+}
+
+const (
+	QueueMessageStatusReady    = "ready"
+	QueueMessageStatusUnacked  = "unacked"
+	QueueMessageStatusRejected = "rejected"
+)
+
+// QueueMessage is a delivery of the mysql backed queue.
+type QueueMessage struct {
+	lib.BaseModel
+	QueueName string
+	Payload   string
+	Status    string
+	TakenAt   *time.Time // refreshed while a worker holds the delivery
+}
@@ -0,0 +1,108 @@
+package queue
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+// memoryQueue is a channel backed queue living in the current process.
+// Deliveries which are not acked yet are lost when the process stops, so it only fits a single node deployment.
+type memoryQueue struct {
+	ready     chan string
+	mu        sync.Mutex
+	rejected  []string
+	consuming bool
+}
+
+func newMemoryQueue(bufferSize int) *memoryQueue {
+	return &memoryQueue{
+		ready: make(chan string, bufferSize),
+	}
+}
+
+func (q *memoryQueue) Publish(payload ...string) error {
+	for _, p := range payload {
+		select {
+		case q.ready <- p:
+		default:
+			return lib.ErrQueueFull
+		}
+	}
+	return nil
+}
+
+// StartConsuming only marks the queue as consuming, since the deliveries are handed over the channel as soon as they are published.
+func (q *memoryQueue) StartConsuming(prefetchLimit int64, pollDuration time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.consuming = true
+	return nil
+}
+
+func (q *memoryQueue) AddConsumer(tag string, consumer Consumer) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.consuming {
+		return "", lib.ErrQueueNotConsuming
+	}
+	go func() {
+		for payload := range q.ready {
+			consumer.Consume(&memoryDelivery{
+				queue:   q,
+				payload: payload,
+			})
+		}
+	}()
+	return tag, nil
+}
+
+func (q *memoryQueue) ReturnRejected(max int64) (int64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var count int64
+	for len(q.rejected) > 0 && count < max {
+		select {
+		case q.ready <- q.rejected[0]:
+			q.rejected = q.rejected[1:]
+			count++
+		default:
+			return count, lib.ErrQueueFull
+		}
+	}
+	return count, nil
+}
+
+func (q *memoryQueue) reject(payload string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rejected = append(q.rejected, payload)
+}
+
+type memoryDelivery struct {
+	queue    *memoryQueue
+	payload  string
+	finished bool
+}
+
+func (d *memoryDelivery) Payload() string {
+	return d.payload
+}
+
+func (d *memoryDelivery) Ack() error {
+	if d.finished {
+		return lib.ErrDeliveryAlreadyFinished
+	}
+	d.finished = true
+	return nil
+}
+
+func (d *memoryDelivery) Reject() error {
+	if d.finished {
+		return lib.ErrDeliveryAlreadyFinished
+	}
+	d.finished = true
+	d.queue.reject(d.payload)
+	return nil
+}
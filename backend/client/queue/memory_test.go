@@ -0,0 +1,146 @@
+package queue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+// channelConsumer hands every delivery over to the test, which acks or rejects it.
+type channelConsumer struct {
+	deliveries chan Delivery
+}
+
+func (c *channelConsumer) Consume(delivery Delivery) {
+	c.deliveries <- delivery
+}
+
+func newConsumingMemoryQueue(t *testing.T, bufferSize int) (*memoryQueue, *channelConsumer) {
+	t.Helper()
+	q := newMemoryQueue(bufferSize)
+	if err := q.StartConsuming(int64(bufferSize), time.Millisecond); err != nil {
+		t.Fatalf("StartConsuming() error = %v", err)
+	}
+	consumer := &channelConsumer{deliveries: make(chan Delivery)}
+	if _, err := q.AddConsumer("test", consumer); err != nil {
+		t.Fatalf("AddConsumer() error = %v", err)
+	}
+	return q, consumer
+}
+
+func receive(t *testing.T, consumer *channelConsumer) Delivery {
+	t.Helper()
+	select {
+	case delivery := <-consumer.deliveries:
+		return delivery
+	case <-time.After(time.Second):
+		t.Fatal("no delivery received")
+		return nil
+	}
+}
+
+func TestMemoryQueuePublishFull(t *testing.T) {
+	q := newMemoryQueue(1)
+	if err := q.Publish("first"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := q.Publish("second"); !errors.Is(err, lib.ErrQueueFull) {
+		t.Fatalf("Publish() error = %v, want %v", err, lib.ErrQueueFull)
+	}
+}
+
+func TestMemoryQueueAddConsumerBeforeStartConsuming(t *testing.T) {
+	q := newMemoryQueue(1)
+	if _, err := q.AddConsumer("test", &channelConsumer{}); !errors.Is(err, lib.ErrQueueNotConsuming) {
+		t.Fatalf("AddConsumer() error = %v, want %v", err, lib.ErrQueueNotConsuming)
+	}
+}
+
+func TestMemoryQueueConsumeInOrder(t *testing.T) {
+	q, consumer := newConsumingMemoryQueue(t, 2)
+	if err := q.Publish("first", "second"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	for _, want := range []string{"first", "second"} {
+		delivery := receive(t, consumer)
+		if delivery.Payload() != want {
+			t.Fatalf("Payload() = %q, want %q", delivery.Payload(), want)
+		}
+		if err := delivery.Ack(); err != nil {
+			t.Fatalf("Ack() error = %v", err)
+		}
+	}
+}
+
+func TestMemoryDeliveryFinishesOnce(t *testing.T) {
+	q, consumer := newConsumingMemoryQueue(t, 1)
+	if err := q.Publish("payload"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	delivery := receive(t, consumer)
+	if err := delivery.Ack(); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+	if err := delivery.Ack(); !errors.Is(err, lib.ErrDeliveryAlreadyFinished) {
+		t.Fatalf("second Ack() error = %v, want %v", err, lib.ErrDeliveryAlreadyFinished)
+	}
+	if err := delivery.Reject(); !errors.Is(err, lib.ErrDeliveryAlreadyFinished) {
+		t.Fatalf("Reject() after Ack() error = %v, want %v", err, lib.ErrDeliveryAlreadyFinished)
+	}
+}
+
+func TestMemoryQueueReturnRejected(t *testing.T) {
+	q, consumer := newConsumingMemoryQueue(t, 1)
+	if err := q.Publish("payload"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if err := receive(t, consumer).Reject(); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	count, err := q.ReturnRejected(10)
+	if err != nil {
+		t.Fatalf("ReturnRejected() error = %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("ReturnRejected() = %d, want 1", count)
+	}
+
+	delivery := receive(t, consumer)
+	if delivery.Payload() != "payload" {
+		t.Fatalf("Payload() = %q, want %q", delivery.Payload(), "payload")
+	}
+	if err := delivery.Ack(); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+
+	count, err = q.ReturnRejected(10)
+	if err != nil {
+		t.Fatalf("ReturnRejected() error = %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("ReturnRejected() after ack = %d, want 0", count)
+	}
+}
+
+func TestMemoryQueueReturnRejectedUpToMax(t *testing.T) {
+	q := newMemoryQueue(3)
+	q.reject("first")
+	q.reject("second")
+	q.reject("third")
+
+	count, err := q.ReturnRejected(2)
+	if err != nil {
+		t.Fatalf("ReturnRejected() error = %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("ReturnRejected() = %d, want 2", count)
+	}
+	if len(q.rejected) != 1 || q.rejected[0] != "third" {
+		t.Fatalf("rejected = %v, want [third]", q.rejected)
+	}
+}
@@ -0,0 +1,238 @@
+package queue
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// mysqlQueue keeps the deliveries in the queue_messages table, and polls the ready ones into the consumers.
+// Rows are locked with SKIP LOCKED while being taken, so several worker processes can consume the same queue.
+// A taken delivery keeps its taken_at refreshed until it is acked or rejected, so the deliveries of a worker which died are returned once it goes stale.
+type mysqlQueue struct {
+	db             *gorm.DB
+	name           string
+	unackedTimeout time.Duration
+	mu             sync.Mutex
+	deliveries     chan *mysqlDelivery
+	held           map[uint]bool // ids of the taken deliveries which are not acked nor rejected yet
+}
+
+func newMySQLQueue(db *gorm.DB, name string, unackedTimeout time.Duration) *mysqlQueue {
+	return &mysqlQueue{
+		db:             db,
+		name:           name,
+		unackedTimeout: unackedTimeout,
+		held:           map[uint]bool{},
+	}
+}
+
+func (q *mysqlQueue) Publish(payload ...string) error {
+	if len(payload) == 0 {
+		return nil
+	}
+	messages := make([]*QueueMessage, 0, len(payload))
+	for _, p := range payload {
+		messages = append(messages, &QueueMessage{
+			QueueName: q.name,
+			Payload:   p,
+			Status:    QueueMessageStatusReady,
+		})
+	}
+	return q.db.Create(&messages).Error
+}
+
+func (q *mysqlQueue) StartConsuming(prefetchLimit int64, pollDuration time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.deliveries != nil {
+		return nil
+	}
+	q.deliveries = make(chan *mysqlDelivery, prefetchLimit)
+	go q.poll(prefetchLimit, pollDuration)
+	go q.heartbeat()
+	return nil
+}
+
+func (q *mysqlQueue) AddConsumer(tag string, consumer Consumer) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.deliveries == nil {
+		return "", lib.ErrQueueNotConsuming
+	}
+	go func(deliveries chan *mysqlDelivery) {
+		for delivery := range deliveries {
+			consumer.Consume(delivery)
+		}
+	}(q.deliveries)
+	return tag, nil
+}
+
+func (q *mysqlQueue) ReturnRejected(max int64) (int64, error) {
+	var ids []uint
+	err := q.db.Model(&QueueMessage{}).
+		Where("queue_name = ? AND status = ?", q.name, QueueMessageStatusRejected).
+		Order("id").
+		Limit(int(max)).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	res := q.db.Model(&QueueMessage{}).
+		Where("id IN ? AND status = ?", ids, QueueMessageStatusRejected).
+		Update("status", QueueMessageStatusReady)
+	return res.RowsAffected, res.Error
+}
+
+// poll takes the ready deliveries as unacked, up to the free room of the prefetch buffer, whenever the poll duration passes
+func (q *mysqlQueue) poll(prefetchLimit int64, pollDuration time.Duration) {
+	ticker := time.NewTicker(pollDuration)
+	defer ticker.Stop()
+	for range ticker.C {
+		room := prefetchLimit - int64(len(q.deliveries))
+		if room <= 0 {
+			continue
+		}
+		messages, err := q.take(room)
+		if err != nil {
+			log.Println("[queue][mysqlQueue][poll] failed to take deliveries:", err.Error())
+			continue
+		}
+		for _, message := range messages {
+			q.deliveries <- &mysqlDelivery{
+				queue:   q,
+				message: message,
+			}
+		}
+	}
+}
+
+func (q *mysqlQueue) take(limit int64) ([]*QueueMessage, error) {
+	var messages []*QueueMessage
+	err := q.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("queue_name = ? AND status = ?", q.name, QueueMessageStatusReady).
+			Order("id").
+			Limit(int(limit)).
+			Find(&messages).Error
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		ids := make([]uint, 0, len(messages))
+		for _, message := range messages {
+			ids = append(ids, message.ID)
+		}
+		return tx.Model(&QueueMessage{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":   QueueMessageStatusUnacked,
+				"taken_at": time.Now(),
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, message := range messages {
+		q.held[message.ID] = true
+	}
+	return messages, nil
+}
+
+// heartbeat refreshes the taken deliveries of this process, and returns the ones no process refreshed within the unacked timeout
+func (q *mysqlQueue) heartbeat() {
+	ticker := time.NewTicker(q.unackedTimeout / 3)
+	defer ticker.Stop()
+	for range ticker.C {
+		ids := q.heldIDs()
+		if len(ids) > 0 {
+			err := q.db.Model(&QueueMessage{}).
+				Where("id IN ? AND status = ?", ids, QueueMessageStatusUnacked).
+				Update("taken_at", time.Now()).Error
+			if err != nil {
+				log.Println("[queue][mysqlQueue][heartbeat] failed to refresh taken deliveries:", err.Error())
+			}
+		}
+
+		res := q.db.Model(&QueueMessage{}).
+			Where("queue_name = ? AND status = ? AND taken_at < ?", q.name, QueueMessageStatusUnacked, time.Now().Add(-q.unackedTimeout)).
+			Updates(map[string]interface{}{
+				"status":   QueueMessageStatusReady,
+				"taken_at": nil,
+			})
+		if res.Error != nil {
+			log.Println("[queue][mysqlQueue][heartbeat] failed to return stale deliveries:", res.Error.Error())
+		} else if res.RowsAffected > 0 {
+			log.Println("[queue][mysqlQueue][heartbeat] returned stale deliveries:", res.RowsAffected)
+		}
+	}
+}
+
+func (q *mysqlQueue) heldIDs() []uint {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ids := make([]uint, 0, len(q.held))
+	for id := range q.held {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (q *mysqlQueue) release(id uint) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.held, id)
+}
+
+type mysqlDelivery struct {
+	queue   *mysqlQueue
+	message *QueueMessage
+}
+
+func (d *mysqlDelivery) Payload() string {
+	return d.message.Payload
+}
+
+// Ack removes the row for good, since a consumed delivery has nothing left to keep.
+func (d *mysqlDelivery) Ack() error {
+	defer d.queue.release(d.message.ID)
+	res := d.queue.db.Unscoped().
+		Where("status = ?", QueueMessageStatusUnacked).
+		Delete(&QueueMessage{}, d.message.ID)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return lib.ErrDeliveryAlreadyFinished
+	}
+	return nil
+}
+
+func (d *mysqlDelivery) Reject() error {
+	defer d.queue.release(d.message.ID)
+	res := d.queue.db.Model(&QueueMessage{}).
+		Where("id = ? AND status = ?", d.message.ID, QueueMessageStatusUnacked).
+		Updates(map[string]interface{}{
+			"status":   QueueMessageStatusRejected,
+			"taken_at": nil,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return lib.ErrDeliveryAlreadyFinished
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package queue
+
+import (
+	"time"
+
+	rmq "github.com/adjust/rmq/v5"
+)
+
+type rmqQueue struct {
+	queue rmq.Queue
+}
+
+func (q *rmqQueue) Publish(payload ...string) error {
+	return q.queue.Publish(payload...)
+}
+
+func (q *rmqQueue) StartConsuming(prefetchLimit int64, pollDuration time.Duration) error {
+	return q.queue.StartConsuming(prefetchLimit, pollDuration)
+}
+
+func (q *rmqQueue) AddConsumer(tag string, consumer Consumer) (string, error) {
+	return q.queue.AddConsumer(tag, &rmqConsumer{consumer: consumer})
+}
+
+func (q *rmqQueue) ReturnRejected(max int64) (int64, error) {
+	return q.queue.ReturnRejected(max)
+}
+
+// rmqConsumer hands rmq deliveries to a queue consumer, which an rmq delivery already satisfies.
+type rmqConsumer struct {
+	consumer Consumer
+}
+
+func (c *rmqConsumer) Consume(delivery rmq.Delivery) {
+	c.consumer.Consume(delivery)
+}
@@ -0,0 +1,61 @@
+package queue
+
+import (
+	rmq "github.com/adjust/rmq/v5"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	redis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	OpenQueue(name string) (Queue, error)
+}
+
+type service struct {
+	cfg           config.QueueConfig
+	db            *gorm.DB
+	rmqConnection rmq.Connection
+}
+
+// NewService opens the configured queue backend. The redis client is only used by the rmq backend, and the database only by the mysql backend.
+func NewService(
+	cfg config.QueueConfig,
+	redisClient *redis.Client,
+	db *gorm.DB,
+) Service {
+	svc := &service{
+		cfg: cfg,
+		db:  db,
+	}
+	switch cfg.Backend {
+	case constants.QueueBackendRMQ:
+		connection, err := rmq.OpenConnectionWithRedisClient(constants.Examitsu, redisClient, nil)
+		if err != nil {
+			panic(err)
+		}
+		svc.rmqConnection = connection
+	case constants.QueueBackendMemory, constants.QueueBackendMySQL:
+	default:
+		panic(lib.ErrUnknownQueueBackend)
+	}
+	return svc
+}
+
+func (s *service) OpenQueue(name string) (Queue, error) {
+	switch s.cfg.Backend {
+	case constants.QueueBackendRMQ:
+		queue, err := s.rmqConnection.OpenQueue(name)
+		if err != nil {
+			return nil, err
+		}
+		return &rmqQueue{queue: queue}, nil
+	case constants.QueueBackendMemory:
+		return newMemoryQueue(s.cfg.MemoryBufferSize), nil
+	case constants.QueueBackendMySQL:
+		return newMySQLQueue(s.db, name, s.cfg.MySQLUnackedTimeout), nil
+	default:
+		return nil, lib.ErrUnknownQueueBackend
+	}
+}
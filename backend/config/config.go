@@ -19,18 +19,43 @@ type Config struct {
 	CacheTTL                        time.Duration `envconfig:"CACHE_TTL" default:"2h"`
 	Role                            string        `envconfig:"ROLE" default:""`
 
-	UpdateAnswerQueuePrefetchLimit int64 `envconfig:"UPDATE_ANSWER_QUEUE_PREFETCH_LIMIT" default:"50"`
+	UpdateAnswerQueuePrefetchLimit     int64 `envconfig:"UPDATE_ANSWER_QUEUE_PREFETCH_LIMIT" default:"50"`
+	UpdateFlagQueuePrefetchLimit       int64 `envconfig:"UPDATE_FLAG_QUEUE_PREFETCH_LIMIT" default:"50"`
+	AnswerSimilarityQueuePrefetchLimit int64 `envconfig:"ANSWER_SIMILARITY_QUEUE_PREFETCH_LIMIT" default:"1"`
+
+	// a failed answer or flag update is retried with a doubling backoff, and is moved to the dead letters once the attempts run out
+	UpdateAnswerMaxAttempts        int           `envconfig:"UPDATE_ANSWER_MAX_ATTEMPTS" default:"5"`
+	UpdateAnswerRetryBackoff       time.Duration `envconfig:"UPDATE_ANSWER_RETRY_BACKOFF" default:"200ms"`
+	UpdateFlagMaxAttempts          int           `envconfig:"UPDATE_FLAG_MAX_ATTEMPTS" default:"5"`
+	UpdateFlagRetryBackoff         time.Duration `envconfig:"UPDATE_FLAG_RETRY_BACKOFF" default:"200ms"`
+	RejectedDeliveryReturnInterval time.Duration `envconfig:"REJECTED_DELIVERY_RETURN_INTERVAL" default:"1m"`
+
+	// cached answers are flushed into the database on this interval, which has to stay well below the cache ttl
+	SubmissionReconciliationInterval time.Duration `envconfig:"SUBMISSION_RECONCILIATION_INTERVAL" default:"15m"`
+
+	// participants which ran out of time are submitted by the worker on this interval
+	ExpiredParticipantFinalizationInterval time.Duration `envconfig:"EXPIRED_PARTICIPANT_FINALIZATION_INTERVAL" default:"1m"`
+
+	// pairs of participants with a lower answer similarity score are not stored
+	AnswerSimilarityMinimumScore float64 `envconfig:"ANSWER_SIMILARITY_MINIMUM_SCORE" default:"2"`
+
+	// the proctor dashboard stream batches exam events per flush interval, and resends every participant per refresh interval
+	ProctorDashboardFlushInterval   time.Duration `envconfig:"PROCTOR_DASHBOARD_FLUSH_INTERVAL" default:"1s"`
+	ProctorDashboardRefreshInterval time.Duration `envconfig:"PROCTOR_DASHBOARD_REFRESH_INTERVAL" default:"30s"`
 
 	MySQLConfig   MySQLConfig
 	AuthConfig    AuthConfig
 	RedisConfig   RedisConfig
 	StorageConfig StorageConfig
+	QueueConfig   QueueConfig
 }
 
 type AuthConfig struct {
-	LoginTokenExpirationDuration time.Duration `envconfig:"LOGIN_TOKEN_EXPIRATION_DURATION" default:"168h"`
-	ApplicationName              string        `envconfig:"APPLICATION_NAME" default:"examitsu"`
-	SignatureKey                 []byte        `envconfig:"JWT_SIGNATURE_KEY" default:""`
+	LoginTokenExpirationDuration  time.Duration `envconfig:"LOGIN_TOKEN_EXPIRATION_DURATION" default:"168h"`
+	StreamTokenExpirationDuration time.Duration `envconfig:"STREAM_TOKEN_EXPIRATION_DURATION" default:"1m"` // only needs to outlive opening the event stream
+	ApplicationName               string        `envconfig:"APPLICATION_NAME" default:"examitsu"`
+	SignatureKey                  []byte        `envconfig:"JWT_SIGNATURE_KEY" default:""`
+	ReceiptSignatureKey           []byte        `envconfig:"RECEIPT_SIGNATURE_KEY" default:""`
 }
 
 type MySQLConfig struct {
@@ -60,6 +85,16 @@ type StorageConfig struct {
 	BucketName              string        `envconfig:"BUCKET_NAME" default:"examitsu"`
 }
 
+// QueueConfig selects where the queues live: rmq on redis, an in-process memory queue, or a mysql table.
+// The memory backend is not shared between processes, so the consumers run inside the api process when it is selected.
+type QueueConfig struct {
+	Backend          string `envconfig:"QUEUE_BACKEND" default:"rmq"`
+	MemoryBufferSize int    `envconfig:"QUEUE_MEMORY_BUFFER_SIZE" default:"10000"`
+
+	// deliveries of the mysql backend taken by a worker which stopped refreshing them for this long are returned to the ready ones
+	MySQLUnackedTimeout time.Duration `envconfig:"QUEUE_MYSQL_UNACKED_TIMEOUT" default:"5m"`
+}
+
 func Get() *Config {
 	err := godotenv.Load()
 	if err != nil {
@@ -67,5 +102,10 @@ func Get() *Config {
 	}
 	cfg := Config{}
 	envconfig.MustProcess("", &cfg)
+
+	// receipts signed with an empty key could be forged by anyone
+	if len(cfg.AuthConfig.ReceiptSignatureKey) == 0 {
+		log.Fatalf("[config] RECEIPT_SIGNATURE_KEY must be set")
+	}
 	return &cfg
 }
@@ -1,22 +1,29 @@
 package constants
 
 const (
-	Examitsu  = "examitsu"
-	Success   = "success"
-	JWTClaims = "jwt_claims"
-	Error     = "error"
-	Worker    = "worker"
+	Examitsu    = "examitsu"
+	Success     = "success"
+	JWTClaims   = "jwt_claims"
+	ExamSession = "exam_session"
+	Error       = "error"
+	Worker      = "worker"
 
-	SystemUser  = "SYSTEM"
-	ProctorUser = "PROCTOR"
+	SystemUser        = "SYSTEM"
+	ProctorUser       = "PROCTOR"
+	ProctorStreamUser = "PROCTOR_STREAM"
 
 	ID          = "id"
 	Serial      = "serial"
+	Token       = "token"
 	IsOpen      = "is_open"
 	ExamID      = "exam_id"
 	OrderNumber = "order_number"
 	None        = "NONE"
 	File        = "file"
+	Subject     = "subject"
+	Grade       = "grade"
+	Topic       = "topic"
+	Difficulty  = "difficulty"
 
 	QueryParameterPage                 = "page"
 	DefaultValueQueryParameterPage     = "1"
@@ -24,15 +31,42 @@ const (
 	DefaultValueQueryParameterPageSize = "10"
 	DefaultQueryPaginationPage         = 1
 	DefaultQueryPaginationPageSize     = 10
+	QueryParameterFormat               = "format"
+	FormatCSV                          = "csv"
 
 	InsertionBatchSize = 100
 
+	RejectedDeliveryReturnBatchSize = 100
+	ReceiptRetryBatchSize           = 100
+	CacheScanCount                  = 1000
+	MaxSyncAnswersBatchSize         = 500
+
 	ExamSessionSubmissionCacheObjectKeyPrefix = "ExamSessionSubmissionCacheObject"
+	ExamSessionSubmissionIdempotencyKeyPrefix = "ExamSessionSubmissionIdempotencyKey"
+	ExamSessionFlagCacheObjectKeyPrefix       = "ExamSessionFlagCacheObject"
 	UpdateAnswerQueueName                     = "updateAnswerQueue"
 	UpdateAnswerConsumerName                  = "updateAnswerConsumer"
+	UpdateFlagQueueName                       = "updateFlagQueue"
+	UpdateFlagConsumerName                    = "updateFlagConsumer"
+	AnswerSimilarityQueueName                 = "answerSimilarityQueue"
+	AnswerSimilarityConsumerName              = "answerSimilarityConsumer"
+
+	QueueBackendRMQ    = "rmq"
+	QueueBackendMemory = "memory"
+	QueueBackendMySQL  = "mysql"
 
 	DefaultRandomQuestionBlobFilenameLength = 64
 
+	ParticipantStateNotStarted              = "not_started"
+	ParticipantStateWaitingForAuthorization = "waiting_for_authorization"
+	ParticipantStateInProgress              = "in_progress"
+	ParticipantStatePaused                  = "paused"
+	ParticipantStateSubmitted               = "submitted"
+	ParticipantStateExpired                 = "expired"
+
+	SnapshotEvent    = "snapshot"
+	ParticipantEvent = "participant"
+
 	UjianCSV   = "ujian.csv"
 	SoalCSV    = "soal.csv"
 	KunciCSV   = "kunci.csv"
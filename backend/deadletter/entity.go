@@ -0,0 +1,43 @@
+package deadletter
+
+import (
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"gorm.io/gorm"
+)
+
+// DeadLetter is a queue delivery that kept failing, kept with its failure reason until an admin replays it.
+type DeadLetter struct {
+	lib.BaseModel
+	QueueName  string
+	Payload    string
+	Reason     string
+	Attempts   int
+	ReplayedAt *time.Time
+}
+
+type GetDeadLettersFilter struct {
+	QueueNameEqualsTo  *lib.QueryFiltersEqualToString `json:"queue_name_equals_to"`
+	IsReplayedEqualsTo *lib.QueryFiltersEqualBool     `json:"is_replayed_equals_to"`
+}
+
+func (f *GetDeadLettersFilter) Scope() []func(db *gorm.DB) *gorm.DB {
+	scopes := []func(db *gorm.DB) *gorm.DB{}
+
+	if f.QueueNameEqualsTo != nil {
+		scopes = append(scopes, f.QueueNameEqualsTo.Scope("queue_name"))
+	}
+
+	if f.IsReplayedEqualsTo != nil {
+		isReplayed := f.IsReplayedEqualsTo.Value
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			if isReplayed {
+				return db.Where("replayed_at IS NOT NULL")
+			}
+			return db.Where("replayed_at IS NULL")
+		})
+	}
+
+	return scopes
+}
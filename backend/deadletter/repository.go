@@ -0,0 +1,76 @@
+package deadletter
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	redis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CreateDeadLetter(deadLetter *DeadLetter) (*DeadLetter, error)
+	GetDeadLetters(pagination *lib.QueryPagination, filter *GetDeadLettersFilter) ([]*DeadLetter, error)
+	GetDeadLetterByID(id uint) (*DeadLetter, error)
+	MarkDeadLetterAsReplayed(id uint) error
+	UnmarkDeadLetterAsReplayed(id uint) error
+}
+
+type repository struct {
+	cfg   *config.Config
+	db    *gorm.DB
+	cache *redis.Client
+}
+
+func NewRepository(
+	cfg *config.Config,
+	db *gorm.DB,
+	cache *redis.Client,
+) Repository {
+	return &repository{
+		cfg:   cfg,
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (r *repository) CreateDeadLetter(deadLetter *DeadLetter) (*DeadLetter, error) {
+	err := r.db.Create(deadLetter).Error
+	return deadLetter, err
+}
+
+func (r *repository) GetDeadLetters(pagination *lib.QueryPagination, filter *GetDeadLettersFilter) ([]*DeadLetter, error) {
+	var res []*DeadLetter
+	err := r.db.Scopes(append(filter.Scope(), pagination.Scope())...).Find(&res).Error
+	return res, err
+}
+
+func (r *repository) GetDeadLetterByID(id uint) (*DeadLetter, error) {
+	var deadLetter DeadLetter
+	err := r.db.Where("id = ?", id).First(&deadLetter).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, lib.ErrDeadLetterNotFound
+		}
+		return nil, err
+	}
+	return &deadLetter, nil
+}
+
+func (r *repository) MarkDeadLetterAsReplayed(id uint) error {
+	res := r.db.Model(&DeadLetter{}).Where("id = ? AND replayed_at IS NULL", id).Update("replayed_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return lib.ErrDeadLetterAlreadyReplayed
+	}
+	return nil
+}
+
+// UnmarkDeadLetterAsReplayed releases a dead letter whose replay could not be published, so it can be replayed again.
+func (r *repository) UnmarkDeadLetterAsReplayed(id uint) error {
+	return r.db.Model(&DeadLetter{}).Where("id = ?", id).Update("replayed_at", nil).Error
+}
@@ -0,0 +1,95 @@
+package deadletter
+
+import (
+	"errors"
+	"log"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/client/queue"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+type Service interface {
+	RecordDeadLetter(queueName string, payload string, reason string, attempts int) error
+	GetDeadLetters(pagination *lib.QueryPagination, filter *GetDeadLettersFilter) ([]*DeadLetter, error)
+	ReplayDeadLetterByID(id uint) error
+}
+
+type service struct {
+	deadLetterRepository Repository
+	queues               map[string]queue.Queue // queues a dead letter can be replayed to, by queue name
+}
+
+func NewService(
+	deadLetterRepository Repository,
+	queues map[string]queue.Queue,
+) Service {
+	return &service{
+		deadLetterRepository: deadLetterRepository,
+		queues:               queues,
+	}
+}
+
+func (s *service) RecordDeadLetter(queueName string, payload string, reason string, attempts int) error {
+	_, err := s.deadLetterRepository.CreateDeadLetter(&DeadLetter{
+		QueueName: queueName,
+		Payload:   payload,
+		Reason:    reason,
+		Attempts:  attempts,
+	})
+	if err != nil {
+		log.Println("[deadletter][service][RecordDeadLetter] failed to record dead letter:", err.Error())
+		return lib.ErrFailedToRecordDeadLetter
+	}
+	return nil
+}
+
+func (s *service) GetDeadLetters(pagination *lib.QueryPagination, filter *GetDeadLettersFilter) ([]*DeadLetter, error) {
+	pagination.Sort = "id DESC"
+	res, err := s.deadLetterRepository.GetDeadLetters(pagination, filter)
+	if err != nil {
+		log.Println("[deadletter][service][GetDeadLetters] failed to get dead letters:", err.Error())
+		return nil, lib.ErrFailedToGetDeadLetters
+	}
+	return res, nil
+}
+
+func (s *service) ReplayDeadLetterByID(id uint) error {
+	deadLetter, err := s.deadLetterRepository.GetDeadLetterByID(id)
+	if err != nil {
+		log.Println("[deadletter][service][ReplayDeadLetterByID] failed to get dead letter:", err.Error())
+		if errors.Is(err, lib.ErrDeadLetterNotFound) {
+			return err
+		}
+		return lib.ErrFailedToReplayDeadLetter
+	}
+	if deadLetter.ReplayedAt != nil {
+		return lib.ErrDeadLetterAlreadyReplayed
+	}
+
+	targetQueue, ok := s.queues[deadLetter.QueueName]
+	if !ok {
+		log.Println("[deadletter][service][ReplayDeadLetterByID] unknown queue:", deadLetter.QueueName)
+		return lib.ErrFailedToReplayDeadLetter
+	}
+
+	// marked first, so a dead letter is never published twice by concurrent replays
+	err = s.deadLetterRepository.MarkDeadLetterAsReplayed(deadLetter.ID)
+	if err != nil {
+		log.Println("[deadletter][service][ReplayDeadLetterByID] failed to mark dead letter as replayed:", err.Error())
+		if errors.Is(err, lib.ErrDeadLetterAlreadyReplayed) {
+			return err
+		}
+		return lib.ErrFailedToReplayDeadLetter
+	}
+
+	err = targetQueue.Publish(deadLetter.Payload)
+	if err != nil {
+		log.Println("[deadletter][service][ReplayDeadLetterByID] failed to publish dead letter:", err.Error())
+		// released again, otherwise the payload could never be replayed once the publish failed
+		if err := s.deadLetterRepository.UnmarkDeadLetterAsReplayed(deadLetter.ID); err != nil {
+			log.Println("[deadletter][service][ReplayDeadLetterByID] failed to unmark dead letter:", err.Error())
+		}
+		return lib.ErrFailedToReplayDeadLetter
+	}
+	return nil
+}
@@ -14,6 +14,17 @@ type Exam struct {
 	Name                   string
 	IsOpen                 bool
 	AllowedDurationMinutes uint
+
+	// scoring policy, applied when computing the participants' points
+	WrongAnswerPenalty int  // subtracted from the point of an answer with no positive point
+	BlankAnswerPoint   int  // point of a served question that is not answered
+	MinQuestionPoint   *int // floor of the point of a single question
+	MinExamPoint       *int // floor of the total point
+	MaxExamPoint       *int // cap of the total point
+
+	// grading, on the 0-100 normalized score
+	MaxScore     *int     // maximum achievable point, derived from the served questions if not set
+	PassingScore *float64 // minimum normalized score to pass
 }
 
 type GetExamsFilter struct {
@@ -147,6 +147,21 @@ func (r *repository) UpdateExam(exam *Exam) error {
 			return err
 		}
 
+		if err := tx.Model(&Exam{}).
+			Where("serial = ?", exam.Serial).
+			Updates(map[string]interface{}{
+				"wrong_answer_penalty": exam.WrongAnswerPenalty,
+				"blank_answer_point":   exam.BlankAnswerPoint,
+				"min_question_point":   exam.MinQuestionPoint,
+				"min_exam_point":       exam.MinExamPoint,
+				"max_exam_point":       exam.MaxExamPoint,
+				"max_score":            exam.MaxScore,
+				"passing_score":        exam.PassingScore,
+			}).
+			Error; err != nil {
+			return err
+		}
+
 		var hangingParticipants []*Participant
 		err := r.db.Where("started_at IS NOT NULL AND ended_at IS NULL").Find(&hangingParticipants).Error
 		if err != nil {
@@ -0,0 +1,33 @@
+package examevent
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ExamStarted             = "exam_started"
+	SessionAuthorized       = "session_authorized"
+	SessionRejected         = "session_rejected"
+	SessionRevoked          = "session_revoked"
+	AnswerSubmitted         = "answer_submitted"
+	ExamSubmitted           = "exam_submitted"
+	ParticipantPaused       = "participant_paused"
+	ParticipantResumed      = "participant_resumed"
+	ParticipantTimeExtended = "participant_time_extended"
+)
+
+type ExamEvent struct {
+	Type          string
+	ExamID        uint
+	ParticipantID uint
+	Timestamp     time.Time
+}
+
+func (e *ExamEvent) GetChannel() string {
+	return GetChannelByExamID(e.ExamID)
+}
+
+func GetChannelByExamID(examID uint) string {
+	return fmt.Sprintf("exam_event:examID:%d", examID)
+}
@@ -0,0 +1,84 @@
+package examevent
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	redis "github.com/redis/go-redis/v9"
+)
+
+type Service interface {
+	Publish(eventType string, examID uint, participantID uint)
+	Subscribe(ctx context.Context, examID uint) (<-chan *ExamEvent, error)
+}
+
+type service struct {
+	redisClient *redis.Client
+}
+
+func NewService(
+	redisClient *redis.Client,
+) Service {
+	return &service{
+		redisClient: redisClient,
+	}
+}
+
+// Publish notifies the subscribers of the exam. Failures are only logged, an event is never worth failing the participant's request.
+func (s *service) Publish(eventType string, examID uint, participantID uint) {
+	event := &ExamEvent{
+		Type:          eventType,
+		ExamID:        examID,
+		ParticipantID: participantID,
+		Timestamp:     time.Now(),
+	}
+	payload, _ := json.Marshal(event)
+	err := s.redisClient.Publish(context.Background(), event.GetChannel(), payload).Err()
+	if err != nil {
+		log.Println("[examevent][service][Publish] failed to publish exam event:", err.Error())
+	}
+}
+
+// Subscribe streams the events of the exam until ctx is done, then closes the returned channel.
+func (s *service) Subscribe(ctx context.Context, examID uint) (<-chan *ExamEvent, error) {
+	pubsub := s.redisClient.Subscribe(ctx, GetChannelByExamID(examID))
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		log.Println("[examevent][service][Subscribe] failed to subscribe to exam events:", err.Error())
+		pubsub.Close()
+		return nil, lib.ErrFailedToSubscribeExamEvents
+	}
+
+	res := make(chan *ExamEvent)
+	go func() {
+		defer close(res)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event ExamEvent
+				err := json.Unmarshal([]byte(message.Payload), &event)
+				if err != nil {
+					log.Println("[examevent][service][Subscribe] failed to parse exam event:", err.Error())
+					continue
+				}
+				select {
+				case res <- &event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return res, nil
+}
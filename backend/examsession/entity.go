@@ -0,0 +1,35 @@
+package examsession
+
+import (
+	"github.com/prajnapras19/project-form-exam-sman2/backend/exam"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participantsession"
+)
+
+// ExamSession is everything an exam session endpoint needs once the request passed the validation.
+type ExamSession struct {
+	Participant        *participant.Participant
+	Exam               *exam.Exam
+	ParticipantSession *participantsession.ParticipantSession // the latest authorized session, which the request's token belongs to
+}
+
+// Requirement tells which participant states an exam session endpoint accepts.
+type Requirement struct {
+	SkipClock      bool // only an explicit submission ends the session, the participant may not have started or may have run out of time
+	AllowPaused    bool
+	AllowSubmitted bool
+}
+
+var (
+	// RequireAuthorizedSession is for checking whether the session has been authorized.
+	RequireAuthorizedSession = &Requirement{SkipClock: true}
+
+	// RequireRunningExam is for reading questions and answering, the participant's clock must be running.
+	RequireRunningExam = &Requirement{}
+
+	// RequireStartedExam is for submitting, a paused participant can still finish the exam.
+	RequireStartedExam = &Requirement{AllowPaused: true}
+
+	// RequireSubmittedExam is for fetching the receipt, the participant must have started and may have submitted or run out of time.
+	RequireSubmittedExam = &Requirement{AllowPaused: true, AllowSubmitted: true}
+)
@@ -0,0 +1,112 @@
+package examsession
+
+import (
+	"errors"
+	"log"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/exam"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participantsession"
+)
+
+type Service interface {
+	ValidateExamSession(claims *lib.ExamTokenJWTClaims, examSerial string, requirement *Requirement) (*ExamSession, error)
+}
+
+type service struct {
+	participantService        participant.Service
+	examService               exam.Service
+	participantSessionService participantsession.Service
+}
+
+func NewService(
+	participantService participant.Service,
+	examService exam.Service,
+	participantSessionService participantsession.Service,
+) Service {
+	return &service{
+		participantService:        participantService,
+		examService:               examService,
+		participantSessionService: participantSessionService,
+	}
+}
+
+// ValidateExamSession returns one of the exam session errors in lib when the request must not proceed,
+// or lib.ErrFailedToValidateExamSession when the validation itself failed.
+func (s *service) ValidateExamSession(claims *lib.ExamTokenJWTClaims, examSerial string, requirement *Requirement) (*ExamSession, error) {
+	// a rejected or revoked session is reported as such, whatever the participant's state is
+	tokenSession, err := s.participantSessionService.GetParticipantSessionBySerial(claims.SessionSerial)
+	if err != nil {
+		if !errors.Is(err, lib.ErrParticipantSessionNotFound) {
+			return nil, s.failed(err)
+		}
+	} else {
+		if tokenSession.RevokedAt != nil {
+			return nil, lib.ErrSessionRevoked
+		}
+		if tokenSession.RejectedAt != nil {
+			return nil, lib.ErrSessionRejected
+		}
+	}
+
+	participant, err := s.participantService.GetParticipantByID(claims.ParticipantID)
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantNotFound) {
+			return nil, err
+		}
+		return nil, s.failed(err)
+	}
+
+	if requirement.SkipClock {
+		if participant.EndedAt != nil {
+			return nil, lib.ErrExamAlreadySubmitted
+		}
+	} else {
+		if participant.StartedAt == nil {
+			return nil, lib.ErrExamNotStarted
+		}
+		if participant.IsSubmitted() && !requirement.AllowSubmitted {
+			return nil, lib.ErrExamAlreadySubmitted
+		}
+		if participant.IsPaused() && !requirement.AllowPaused {
+			return nil, lib.ErrExamPaused
+		}
+	}
+
+	exam, err := s.examService.GetExamByID(participant.ExamID)
+	if err != nil {
+		if errors.Is(err, lib.ErrExamNotFound) {
+			return nil, err
+		}
+		return nil, s.failed(err)
+	}
+	if exam.Serial != examSerial {
+		return nil, lib.ErrUnauthorizedRequest
+	}
+	if !exam.IsOpen {
+		return nil, lib.ErrExamNotFound
+	}
+
+	participantSession, err := s.participantSessionService.GetLatestAuthorizedParticipantSessionByParticipantID(participant.ID)
+	if err != nil {
+		if errors.Is(err, lib.ErrParticipantSessionNotFound) {
+			return nil, lib.ErrSessionNotFound
+		}
+		return nil, s.failed(err)
+	}
+	if participantSession.Serial != claims.SessionSerial {
+		return nil, lib.ErrSessionNotFound
+	}
+
+	return &ExamSession{
+		Participant:        participant,
+		Exam:               exam,
+		ParticipantSession: participantSession,
+	}, nil
+}
+
+func (s *service) failed(err error) error {
+	log.Println("[examsession][service][ValidateExamSession] failed to validate exam session:", err.Error())
+	return lib.ErrFailedToValidateExamSession
+}
@@ -0,0 +1,18 @@
+package grading
+
+import (
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+type ExamGradeBand struct {
+	lib.BaseModel
+	ExamID   uint
+	Label    string
+	MinScore float64 // lowest normalized score of the band
+}
+
+type Grade struct {
+	NormalizedScore float64
+	IsPassed        *bool // nil if the exam has no passing score
+	Label           string
+}
@@ -0,0 +1,102 @@
+package grading
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	redis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CreateGradeBand(gradeBand *ExamGradeBand) (*ExamGradeBand, error)
+	GetGradeBandByID(id uint) (*ExamGradeBand, error)
+	GetGradeBandsByExamID(examID uint) ([]*ExamGradeBand, error)
+	DeleteGradeBandByID(id uint) error
+}
+
+type repository struct {
+	cfg   *config.Config
+	db    *gorm.DB
+	cache *redis.Client
+}
+
+func NewRepository(
+	cfg *config.Config,
+	db *gorm.DB,
+	cache *redis.Client,
+) Repository {
+	return &repository{
+		cfg:   cfg,
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (r *repository) CreateGradeBand(gradeBand *ExamGradeBand) (*ExamGradeBand, error) {
+	err := r.db.Create(gradeBand).Error
+	if err == nil {
+		r.cache.Del(context.Background(), r.GetGradeBandsByExamIDCacheKey(gradeBand.ExamID))
+	}
+	return gradeBand, err
+}
+
+func (r *repository) GetGradeBandByID(id uint) (*ExamGradeBand, error) {
+	var gradeBand ExamGradeBand
+	err := r.db.Where("id = ?", id).First(&gradeBand).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, lib.ErrGradeBandNotFound
+		}
+		return nil, err
+	}
+	return &gradeBand, nil
+}
+
+func (r *repository) GetGradeBandsByExamID(examID uint) ([]*ExamGradeBand, error) {
+	var gradeBands []*ExamGradeBand
+
+	cacheKey := r.GetGradeBandsByExamIDCacheKey(examID)
+	val, err := r.cache.Get(context.Background(), cacheKey).Result()
+	if err == nil {
+		json.Unmarshal([]byte(val), &gradeBands)
+		return gradeBands, nil
+	}
+
+	err = r.db.Where("exam_id = ?", examID).Order("min_score DESC").Find(&gradeBands).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res, _ := json.Marshal(gradeBands)
+	r.cache.Set(context.Background(), cacheKey, res, r.cfg.CacheTTL)
+	return gradeBands, nil
+}
+
+func (r *repository) DeleteGradeBandByID(id uint) error {
+	currentData, err := r.GetGradeBandByID(id)
+	if err != nil {
+		return err
+	}
+
+	res := r.db.Model(&ExamGradeBand{}).Where("id = ?", id).Delete(&ExamGradeBand{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Printf("[grading][repository][DeleteGradeBandByID] error: %s", res.Error)
+		return lib.ErrGradeBandNotFound
+	}
+
+	r.cache.Del(context.Background(), r.GetGradeBandsByExamIDCacheKey(currentData.ExamID))
+	return nil
+}
+
+func (r *repository) GetGradeBandsByExamIDCacheKey(examID uint) string {
+	return fmt.Sprintf("grade_band_list:examID:%d", examID)
+}
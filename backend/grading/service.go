@@ -0,0 +1,83 @@
+package grading
+
+import (
+	"errors"
+	"log"
+	"math"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/exam"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+type Service interface {
+	CreateGradeBand(gradeBand *ExamGradeBand) (*ExamGradeBand, error)
+	GetGradeBandsByExamID(examID uint) ([]*ExamGradeBand, error)
+	DeleteGradeBandByID(id uint) error
+
+	GradeParticipant(exam *exam.Exam, gradeBands []*ExamGradeBand, totalPoint int, maxPoint int) *Grade
+}
+
+type service struct {
+	gradingRepository Repository
+}
+
+func NewService(
+	gradingRepository Repository,
+) Service {
+	return &service{
+		gradingRepository: gradingRepository,
+	}
+}
+
+func (s *service) CreateGradeBand(gradeBand *ExamGradeBand) (*ExamGradeBand, error) {
+	res, err := s.gradingRepository.CreateGradeBand(gradeBand)
+	if err != nil {
+		log.Println("[grading][service][CreateGradeBand] failed to create grade band:", err.Error())
+		return nil, lib.ErrFailedToCreateGradeBand
+	}
+	return res, nil
+}
+
+func (s *service) GetGradeBandsByExamID(examID uint) ([]*ExamGradeBand, error) {
+	res, err := s.gradingRepository.GetGradeBandsByExamID(examID)
+	if err != nil {
+		log.Println("[grading][service][GetGradeBandsByExamID] failed to get grade bands:", err.Error())
+		return nil, lib.ErrFailedToGetGradeBands
+	}
+	return res, nil
+}
+
+func (s *service) DeleteGradeBandByID(id uint) error {
+	err := s.gradingRepository.DeleteGradeBandByID(id)
+	if err != nil {
+		log.Println("[grading][service][DeleteGradeBandByID] failed to delete grade band:", err.Error())
+		if errors.Is(err, lib.ErrGradeBandNotFound) {
+			return err
+		}
+		return lib.ErrFailedToDeleteGradeBand
+	}
+	return nil
+}
+
+// GradeParticipant normalizes the total point to a 0-100 score and looks up the passing status and the grade band.
+// gradeBands must be sorted by MinScore descending, as returned by GetGradeBandsByExamID.
+func (s *service) GradeParticipant(exam *exam.Exam, gradeBands []*ExamGradeBand, totalPoint int, maxPoint int) *Grade {
+	res := &Grade{}
+	if maxPoint > 0 {
+		res.NormalizedScore = math.Round(float64(totalPoint)/float64(maxPoint)*10000) / 100
+		res.NormalizedScore = math.Max(0, math.Min(100, res.NormalizedScore))
+	}
+
+	if exam.PassingScore != nil {
+		isPassed := res.NormalizedScore >= *exam.PassingScore
+		res.IsPassed = &isPassed
+	}
+
+	for _, gradeBand := range gradeBands {
+		if res.NormalizedScore >= gradeBand.MinScore {
+			res.Label = gradeBand.Label
+			break
+		}
+	}
+	return res
+}
@@ -9,12 +9,16 @@ var (
 	ErrInsufficientPermission      = errors.New("insufficient permission")
 	ErrFailedToDecodeContent       = errors.New("failed to decode content")
 	ErrFailedToProcessUploadedFile = errors.New("failed to process uploaded file")
+	ErrTooManyAnswers              = errors.New("too many answers")
 
 	// handler.participant
 	ErrExamAlreadySubmitted = errors.New("exam already submitted")
 	ErrExamAlreadyStarted   = errors.New("exam already started")
 	ErrExamNotStarted       = errors.New("exam not started")
 	ErrSessionNotFound      = errors.New("session not found")
+	ErrSessionRejected      = errors.New("session rejected")
+	ErrSessionRevoked       = errors.New("session revoked")
+	ErrExamPaused           = errors.New("exam paused")
 
 	// lib.jwt_claims
 	ErrFailedToParseJWTClaimsInContext = errors.New("failed to parse jwt claims in context")
@@ -43,14 +47,16 @@ var (
 	ErrFailedToDeleteExam      = errors.New("failed to delete exam")
 
 	// question.repository
-	ErrQuestionNotFound = errors.New("question not found")
+	ErrQuestionNotFound     = errors.New("question not found")
+	ErrInvalidQuestionOrder = errors.New("question order must contain every question of the exam exactly once")
 
 	// question.service
-	ErrFailedToCreateQuestion  = errors.New("failed to create question")
-	ErrFailedToGetQuestionByID = errors.New("failed to get question by id")
-	ErrFailedToGetQuestions    = errors.New("failed to get questions")
-	ErrFailedToUpdateQuestion  = errors.New("failed to update question")
-	ErrFailedToDeleteQuestion  = errors.New("failed to delete question")
+	ErrFailedToCreateQuestion   = errors.New("failed to create question")
+	ErrFailedToGetQuestionByID  = errors.New("failed to get question by id")
+	ErrFailedToGetQuestions     = errors.New("failed to get questions")
+	ErrFailedToUpdateQuestion   = errors.New("failed to update question")
+	ErrFailedToDeleteQuestion   = errors.New("failed to delete question")
+	ErrFailedToReorderQuestions = errors.New("failed to reorder questions")
 
 	// mcqoption.repository
 	ErrMcqOptionNotFound = errors.New("mcq option not found")
@@ -63,33 +69,137 @@ var (
 	ErrFailedToDeleteMcqOption = errors.New("failed to delete mcq option")
 
 	// participant.repository
-	ErrParticipantNotFound = errors.New("participant not found")
+	ErrParticipantNotFound      = errors.New("participant not found")
+	ErrParticipantAlreadyPaused = errors.New("participant already paused")
+	ErrParticipantAlreadyEnded  = errors.New("participant already ended")
+	ErrParticipantNotPaused     = errors.New("participant not paused")
 
 	// participant.service
-	ErrFailedToCreateParticipants        = errors.New("failed to create participants")
-	ErrFailedToGetParticipant            = errors.New("failed to get participant")
-	ErrFailedToGetParticipants           = errors.New("failed to get participants")
-	ErrFailedToUpdateParticipant         = errors.New("failed to update participant")
-	ErrFailedToDeleteParticipant         = errors.New("failed to delete participant")
-	ErrFailedToGetParticipantTotalPoints = errors.New("failed to get participant total points")
-	ErrFailedToGetParticipantsAnswers    = errors.New("failed to get participants answers")
+	ErrFailedToCreateParticipants             = errors.New("failed to create participants")
+	ErrFailedToGetParticipant                 = errors.New("failed to get participant")
+	ErrFailedToGetParticipants                = errors.New("failed to get participants")
+	ErrFailedToUpdateParticipant              = errors.New("failed to update participant")
+	ErrFailedToDeleteParticipant              = errors.New("failed to delete participant")
+	ErrFailedToGetParticipantTotalPoints      = errors.New("failed to get participant total points")
+	ErrFailedToGetParticipantsAnswers         = errors.New("failed to get participants answers")
+	ErrFailedToGetParticipantProgresses       = errors.New("failed to get participant progresses")
+	ErrFailedToPauseParticipant               = errors.New("failed to pause participant")
+	ErrFailedToEndParticipant                 = errors.New("failed to end participant")
+	ErrFailedToResumeParticipant              = errors.New("failed to resume participant")
+	ErrFailedToExtendParticipantTime          = errors.New("failed to extend participant time")
+	ErrFailedToUpdateParticipantAccommodation = errors.New("failed to update participant accommodation")
+	ErrFailedToGetQuestionStatistics          = errors.New("failed to get question statistics")
 
 	// submission.repository
-	ErrSubmissionNotFound = errors.New("failed to get submission")
+	ErrSubmissionNotFound     = errors.New("failed to get submission")
+	ErrSubmissionFlagNotFound = errors.New("submission flag not found")
+	ErrInvalidCachedAnswer    = errors.New("invalid cached answer")
+	ErrInvalidCachedFlag      = errors.New("invalid cached flag")
+	ErrAnswersFrozen          = errors.New("answers are frozen by the submission receipt")
 
 	// submission.service
-	ErrFailedToSaveAnswer = errors.New("failed to save answer")
-	ErrAnswerNotFound     = errors.New("answer not found")
-	ErrFailedToGetAnswer  = errors.New("failed to get answer")
+	ErrFailedToSaveAnswer       = errors.New("failed to save answer")
+	ErrAnswerNotFound           = errors.New("answer not found")
+	ErrFailedToGetAnswer        = errors.New("failed to get answer")
+	ErrFailedToGetCachedAnswers = errors.New("failed to get cached answers")
+	ErrFailedToSaveFlag         = errors.New("failed to save flag")
+	ErrFailedToGetFlag          = errors.New("failed to get flag")
+	ErrFailedToFlushAnswers     = errors.New("failed to flush answers")
+	ErrFailedToGetAnswerHistory = errors.New("failed to get answer history")
+	ErrFailedToReconcileAnswer  = errors.New("failed to reconcile answer")
+
+	// questionbank.repository
+	ErrBankQuestionNotFound = errors.New("bank question not found")
+
+	// questionbank.service
+	ErrFailedToCreateBankQuestion    = errors.New("failed to create bank question")
+	ErrFailedToGetBankQuestion       = errors.New("failed to get bank question")
+	ErrFailedToGetBankQuestions      = errors.New("failed to get bank questions")
+	ErrFailedToUpdateBankQuestion    = errors.New("failed to update bank question")
+	ErrFailedToDeleteBankQuestion    = errors.New("failed to delete bank question")
+	ErrFailedToGetBankMcqOptions     = errors.New("failed to get bank mcq options")
+	ErrFailedToSnapshotBankQuestions = errors.New("failed to snapshot bank questions")
+
+	// questionpool.repository
+	ErrExamQuestionPoolNotFound = errors.New("exam question pool not found")
+
+	// questionpool.service
+	ErrFailedToCreateExamQuestionPool   = errors.New("failed to create exam question pool")
+	ErrFailedToGetExamQuestionPools     = errors.New("failed to get exam question pools")
+	ErrFailedToDeleteExamQuestionPool   = errors.New("failed to delete exam question pool")
+	ErrFailedToDrawParticipantQuestions = errors.New("failed to draw participant questions")
+	ErrFailedToGetParticipantQuestions  = errors.New("failed to get participant questions")
+	ErrInsufficientBankQuestionsInPool  = errors.New("insufficient bank questions in pool")
+
+	// answerkey.repository
+	ErrAnswerKeyRevisionNotFound = errors.New("answer key revision not found")
+	ErrAnswerKeyNotChanged       = errors.New("answer key is not changed")
+
+	// answerkey.service
+	ErrFailedToReviseAnswerKey       = errors.New("failed to revise answer key")
+	ErrFailedToGetAnswerKeyRevisions = errors.New("failed to get answer key revisions")
+	ErrFailedToGetAnswerKeyRevision  = errors.New("failed to get answer key revision")
+	ErrPointChangeRequiresRevision   = errors.New("points of a started exam can only be changed through an answer key revision")
+
+	// grading.repository
+	ErrGradeBandNotFound = errors.New("grade band not found")
+
+	// grading.service
+	ErrFailedToCreateGradeBand = errors.New("failed to create grade band")
+	ErrFailedToGetGradeBands   = errors.New("failed to get grade bands")
+	ErrFailedToDeleteGradeBand = errors.New("failed to delete grade band")
+
+	// analytics.service
+	ErrFailedToRequestAnswerSimilarityDetection = errors.New("failed to request answer similarity detection")
+	ErrFailedToDetectAnswerSimilarity           = errors.New("failed to detect answer similarity")
+	ErrFailedToGetAnswerSimilarityPairs         = errors.New("failed to get answer similarity pairs")
 
 	// storage.service
 	ErrFailedToGetUploadURL = errors.New("failed to get upload url")
 
+	// queue.service
+	ErrUnknownQueueBackend     = errors.New("unknown queue backend")
+	ErrQueueFull               = errors.New("queue is full")
+	ErrQueueNotConsuming       = errors.New("queue is not consuming")
+	ErrDeliveryAlreadyFinished = errors.New("delivery already acked or rejected")
+
 	// participantsession.repository
 	ErrParticipantSessionNotFound = errors.New("failed to get participant session")
 
 	// participantsession.service
-	ErrFailedToCreateParticipantSession    = errors.New("failed to create participant session")
-	ErrFailedToGetParticipantSession       = errors.New("failed to get participant session")
-	ErrFailedToAuthorizeParticipantSession = errors.New("failed to authorize participant session")
+	ErrFailedToCreateParticipantSession      = errors.New("failed to create participant session")
+	ErrFailedToGetParticipantSession         = errors.New("failed to get participant session")
+	ErrFailedToAuthorizeParticipantSession   = errors.New("failed to authorize participant session")
+	ErrFailedToGetPendingParticipantSessions = errors.New("failed to get pending participant sessions")
+	ErrFailedToRejectParticipantSession      = errors.New("failed to reject participant session")
+	ErrFailedToRevokeParticipantSession      = errors.New("failed to revoke participant session")
+	ErrFailedToGetParticipantSessionAudits   = errors.New("failed to get participant session audits")
+
+	// examevent.service
+	ErrFailedToSubscribeExamEvents = errors.New("failed to subscribe to exam events")
+
+	// reconciliation.service
+	ErrFailedToReconcileSubmissions         = errors.New("failed to reconcile submissions")
+	ErrFailedToGetSubmissionReconciliations = errors.New("failed to get submission reconciliations")
+	ErrExamNotReconciled                    = errors.New("exam answers are not fully reconciled yet, please run the reconciliation first")
+
+	// receipt.repository
+	ErrReceiptNotFound = errors.New("receipt not found")
+
+	// receipt.service
+	ErrFailedToIssueReceipt  = errors.New("failed to issue receipt")
+	ErrFailedToGetReceipt    = errors.New("failed to get receipt")
+	ErrFailedToVerifyReceipt = errors.New("failed to verify receipt")
+
+	// deadletter.repository
+	ErrDeadLetterNotFound        = errors.New("dead letter not found")
+	ErrDeadLetterAlreadyReplayed = errors.New("dead letter already replayed")
+
+	// deadletter.service
+	ErrFailedToRecordDeadLetter = errors.New("failed to record dead letter")
+	ErrFailedToGetDeadLetters   = errors.New("failed to get dead letters")
+	ErrFailedToReplayDeadLetter = errors.New("failed to replay dead letter")
+
+	// examsession.service
+	ErrFailedToValidateExamSession = errors.New("failed to validate exam session")
 )
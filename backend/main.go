@@ -2,22 +2,33 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"net/http"
 
-	rmq "github.com/adjust/rmq/v5"
 	"github.com/gin-gonic/gin"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/adminauth"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/analytics"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/answerkey"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/api"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/client/mysql"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/client/queue"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/client/redis"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/client/storage"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/deadletter"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/exam"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/examevent"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/examsession"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/grading"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/mcqoption"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/participantsession"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/question"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/questionbank"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/questionpool"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/receipt"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/reconciliation"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/worker"
 )
@@ -35,14 +46,8 @@ func initDefault(cfg *config.Config) {
 	// clients
 	dbmysql := mysql.NewService(cfg.MySQLConfig)
 	dbredis := redis.NewService(cfg.RedisConfig)
-	redisMQConnection, err := rmq.OpenConnectionWithRedisClient(constants.Examitsu, dbredis.GetClient(), nil)
-	if err != nil {
-		panic(err)
-	}
-	updateAnswerQueue, err := redisMQConnection.OpenQueue(constants.UpdateAnswerQueueName)
-	if err != nil {
-		panic(err)
-	}
+	queueService := queue.NewService(cfg.QueueConfig, dbredis.GetClient(), dbmysql.GetDB())
+	updateAnswerQueue, updateFlagQueue, answerSimilarityQueue := openQueues(queueService)
 	storageService := storage.NewService(cfg.StorageConfig)
 
 	// repositories
@@ -52,6 +57,14 @@ func initDefault(cfg *config.Config) {
 	participantRepository := participant.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
 	submissionRepository := submission.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
 	participantSessionRepository := participantsession.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient(), participantRepository)
+	questionBankRepository := questionbank.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient(), questionRepository)
+	questionPoolRepository := questionpool.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	answerKeyRepository := answerkey.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient(), mcqOptionRepository, participantRepository)
+	gradingRepository := grading.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	analyticsRepository := analytics.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	deadLetterRepository := deadletter.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	reconciliationRepository := reconciliation.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	receiptRepository := receipt.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
 
 	// services
 	adminAuthService := adminauth.NewService(cfg)
@@ -59,8 +72,40 @@ func initDefault(cfg *config.Config) {
 	questionService := question.NewService(questionRepository)
 	mcqOptionService := mcqoption.NewService(mcqOptionRepository)
 	participantService := participant.NewService(cfg, participantRepository, examService)
-	submissionService := submission.NewService(submissionRepository, dbredis.GetClient(), updateAnswerQueue)
+	submissionService := submission.NewService(submissionRepository, dbredis.GetClient(), updateAnswerQueue, updateFlagQueue)
 	participantSessionService := participantsession.NewService(participantSessionRepository)
+	questionBankService := questionbank.NewService(questionBankRepository)
+	questionPoolService := questionpool.NewService(questionPoolRepository, questionBankService, questionService)
+	answerKeyService := answerkey.NewService(answerKeyRepository)
+	gradingService := grading.NewService(gradingRepository)
+	analyticsService := analytics.NewService(cfg, answerSimilarityQueue, analyticsRepository, participantService, questionService, mcqOptionService)
+	examEventService := examevent.NewService(dbredis.GetClient())
+	deadLetterService := deadletter.NewService(deadLetterRepository, map[string]queue.Queue{
+		constants.UpdateAnswerQueueName: updateAnswerQueue,
+		constants.UpdateFlagQueueName:   updateFlagQueue,
+	})
+	reconciliationService := reconciliation.NewService(reconciliationRepository, submissionService, participantService, questionService)
+	receiptService := receipt.NewService(cfg, receiptRepository, submissionService)
+	examSessionService := examsession.NewService(participantService, examService, participantSessionService)
+
+	// the memory queues are only reachable from this process, so their consumers run here instead of in a worker
+	if cfg.QueueConfig.Backend == constants.QueueBackendMemory {
+		workerService := newWorkerService(
+			cfg,
+			updateAnswerQueue,
+			updateFlagQueue,
+			answerSimilarityQueue,
+			submissionService,
+			analyticsService,
+			participantService,
+			examEventService,
+			deadLetterService,
+			reconciliationService,
+			questionPoolService,
+			receiptService,
+		)
+		workerService.InitConsumers()
+	}
 
 	// handlers
 	handler := api.NewHandler(
@@ -73,6 +118,15 @@ func initDefault(cfg *config.Config) {
 		submissionService,
 		storageService,
 		participantSessionService,
+		questionBankService,
+		questionPoolService,
+		answerKeyService,
+		gradingService,
+		analyticsService,
+		examEventService,
+		deadLetterService,
+		reconciliationService,
+		receiptService,
 	)
 
 	// routes
@@ -98,6 +152,8 @@ func initDefault(cfg *config.Config) {
 	adminGroup.PATCH("/exams/:serial", handler.UpdateExam)
 	adminGroup.DELETE("/exams/:serial", handler.DeleteExamBySerial)
 	adminGroup.GET("/exams/template", handler.GetExamTemplate)
+	adminGroup.POST("/exams/:serial/bank-questions", handler.SnapshotBankQuestionsToExam)
+	adminGroup.POST("/exams/:serial/questions/order", handler.ReorderQuestions)
 
 	adminGroup.PUT("/questions", handler.CreateQuestion)
 	adminGroup.POST("/questions/file-upload-url", handler.GetUploadQuestionBlobURL)
@@ -106,6 +162,38 @@ func initDefault(cfg *config.Config) {
 	adminGroup.PATCH("/questions/:id", handler.UpdateQuestion)
 	adminGroup.DELETE("/questions/:id", handler.DeleteQuestionBySerial)
 
+	adminGroup.PUT("/bank-questions", handler.CreateBankQuestion)
+	adminGroup.POST("/bank-questions", handler.GetBankQuestions)
+	adminGroup.POST("/bank-questions/:id", handler.GetBankQuestionByID)
+	adminGroup.PATCH("/bank-questions/:id", handler.UpdateBankQuestion)
+	adminGroup.DELETE("/bank-questions/:id", handler.DeleteBankQuestionByID)
+
+	adminGroup.PUT("/exam-question-pools", handler.CreateExamQuestionPool)
+	adminGroup.POST("/exam-question-pools/exam-serial/:serial", handler.GetExamQuestionPoolsByExamSerial)
+	adminGroup.DELETE("/exam-question-pools/:id", handler.DeleteExamQuestionPoolByID)
+
+	adminGroup.PUT("/answer-key-revisions", handler.ReviseAnswerKey)
+	adminGroup.POST("/answer-key-revisions/exam-serial/:serial", handler.GetAnswerKeyRevisionsByExamSerial)
+	adminGroup.POST("/answer-key-revisions/:id", handler.GetAnswerKeyRevisionByID)
+	adminGroup.POST("/answer-key-revisions/:id/download", handler.DownloadAnswerKeyRevision)
+
+	adminGroup.PUT("/grade-bands", handler.CreateGradeBand)
+	adminGroup.POST("/grade-bands/exam-serial/:serial", handler.GetGradeBandsByExamSerial)
+	adminGroup.DELETE("/grade-bands/:id", handler.DeleteGradeBandByID)
+
+	adminGroup.POST("/analytics/exam-serial/:serial/item-analysis", handler.GetItemAnalysis)
+	adminGroup.POST("/analytics/exam-serial/:serial/statistics", handler.GetExamStatistics)
+	adminGroup.POST("/analytics/exam-serial/:serial/answer-similarity", handler.GetAnswerSimilarityPairs)
+	adminGroup.POST("/analytics/exam-serial/:serial/answer-similarity/run", handler.RequestAnswerSimilarityDetection)
+
+	adminGroup.POST("/dead-letters", handler.GetDeadLetters)
+	adminGroup.POST("/dead-letters/:id/replay", handler.ReplayDeadLetter)
+
+	adminGroup.POST("/receipts/verify", handler.VerifyReceiptAnswers)
+
+	adminGroup.POST("/reconciliations/exam-serial/:serial", handler.GetSubmissionReconciliationsByExamSerial)
+	adminGroup.POST("/reconciliations/exam-serial/:serial/run", handler.ReconcileExamSubmissions)
+
 	adminGroup.PUT("/mcq-options", handler.CreateMcqOption)
 	adminGroup.POST("/mcq-options/question-id/:id", handler.GetMcqOptionsByQuestionID)
 	adminGroup.PATCH("/mcq-options/:id", handler.UpdateMcqOption)
@@ -114,53 +202,93 @@ func initDefault(cfg *config.Config) {
 	adminGroup.PUT("/participants", handler.CreateParticipant)
 	adminGroup.POST("/participants/exam-serial/:serial", handler.GetParticipantsByExamSerial)
 	adminGroup.POST("/participants/exam-serial/:serial/report", handler.GetParticipantsReport)
+	adminGroup.POST("/participants/exam-serial/:serial/question-statistics", handler.GetQuestionStatistics)
 	adminGroup.POST("/participants/id/:id", handler.GetParticipantByID)
+	adminGroup.POST("/participants/id/:id/questions", handler.GetParticipantQuestions)
+	adminGroup.POST("/participants/id/:id/answer-history", handler.GetParticipantAnswerHistory)
+	adminGroup.POST("/participants/id/:id/receipt", handler.GetParticipantReceipt)
 	adminGroup.PATCH("/participants/:id", handler.UpdateParticipant)
 	adminGroup.DELETE("/participants/:id", handler.DeleteParticipantByID)
+	adminGroup.PATCH("/participants/:id/accommodation", handler.UpdateParticipantAccommodation)
 
 	apiV1.GET("/exams", handler.GetAllOpenedExams)
 	apiV1.GET("/exams/:serial", handler.GetOpenedExam)
 	apiV1.POST("/exams/:serial/start", handler.StartExam)
+	apiV1.POST("/receipts/verify", handler.VerifyReceipt)
 
 	examSessionGroup := apiV1.Group("/exam-session")
 	examSessionGroup.Use(api.JWTExamTokenMiddleware(participantService))
-	examSessionGroup.GET("/:serial/check", handler.IsSessionAuthorized)
-	examSessionGroup.GET("/:serial/questions", handler.GetQuestionsIDByExamSerial)
-	examSessionGroup.GET("/:serial/questions/:id", handler.GetQuestionWithOptions)
-	examSessionGroup.POST("/:serial/questions/:id", handler.SubmitAnswer)
-	examSessionGroup.POST("/:serial/submit", handler.SubmitExam)
+	examSessionGroup.GET("/:serial/check", api.ExamSessionMiddleware(examSessionService, examsession.RequireAuthorizedSession), handler.IsSessionAuthorized)
+	examSessionGroup.GET("/:serial/questions", api.ExamSessionMiddleware(examSessionService, examsession.RequireRunningExam), handler.GetQuestionsIDByExamSerial)
+	examSessionGroup.GET("/:serial/questions/:id", api.ExamSessionMiddleware(examSessionService, examsession.RequireRunningExam), handler.GetQuestionWithOptions)
+	examSessionGroup.POST("/:serial/questions/:id", api.ExamSessionMiddleware(examSessionService, examsession.RequireRunningExam), handler.SubmitAnswer)
+	examSessionGroup.POST("/:serial/questions/:id/flag", api.ExamSessionMiddleware(examSessionService, examsession.RequireRunningExam), handler.FlagQuestion)
+	examSessionGroup.GET("/:serial/summary", api.ExamSessionMiddleware(examSessionService, examsession.RequireRunningExam), handler.GetExamSessionSummary)
+	examSessionGroup.POST("/:serial/answers/sync", api.ExamSessionMiddleware(examSessionService, examsession.RequireRunningExam), handler.SyncAnswers)
+	examSessionGroup.POST("/:serial/submit", api.ExamSessionMiddleware(examSessionService, examsession.RequireStartedExam), handler.SubmitExam)
+	examSessionGroup.GET("/:serial/receipt", api.ExamSessionMiddleware(examSessionService, examsession.RequireSubmittedExam), handler.GetExamSessionReceipt)
 
 	proctorGroup := apiV1.Group("/proctor")
 	proctorGroup.POST("/login", handler.LoginProctor)
+	proctorGroup.GET("/exams/:serial/participants/stream", api.ProctorStreamTokenMiddleware(adminAuthService), handler.StreamParticipantStates)
 	proctorGroup.Use(api.JWTProctorMiddleware(adminAuthService))
 	proctorGroup.GET("/is-logged-in", handler.IsLoggedInAsProctor)
 	proctorGroup.GET("/participant-sessions/:serial/check", handler.CheckSession)
 	proctorGroup.POST("/participant-sessions/:serial/authorize", handler.AuthorizeSession)
+	proctorGroup.POST("/participant-sessions/:serial/reject", handler.RejectSession)
+	proctorGroup.POST("/participant-sessions/:serial/revoke", handler.RevokeSession)
+	proctorGroup.POST("/exams/:serial/participants/stream-token", handler.CreateParticipantStateStreamToken)
+	proctorGroup.GET("/exams/:serial/participant-sessions/pending", handler.GetPendingParticipantSessions)
+	proctorGroup.POST("/exams/:serial/participant-sessions/authorize", handler.BulkAuthorizeSessions)
+	proctorGroup.POST("/exams/:serial/participant-sessions/reject", handler.BulkRejectSessions)
+	proctorGroup.GET("/exams/:serial/participant-sessions/audits", handler.GetParticipantSessionAudits)
+	proctorGroup.POST("/participants/:id/pause", handler.PauseParticipant)
+	proctorGroup.POST("/participants/:id/resume", handler.ResumeParticipant)
+	proctorGroup.POST("/participants/:id/extend", handler.ExtendParticipantTime)
 
 	router.Run(fmt.Sprintf(":%d", cfg.RESTPort))
 }
 
 func initWorker(cfg *config.Config) {
+	if cfg.QueueConfig.Backend == constants.QueueBackendMemory {
+		log.Fatalln("[main][initWorker] the memory queue backend is consumed by the api process, a separate worker cannot reach it")
+	}
+
 	// clients
 	dbmysql := mysql.NewService(cfg.MySQLConfig)
 	dbredis := redis.NewService(cfg.RedisConfig)
-	redisMQConnection, err := rmq.OpenConnectionWithRedisClient(constants.Examitsu, dbredis.GetClient(), nil)
-	if err != nil {
-		panic(err)
-	}
-	updateAnswerQueue, err := redisMQConnection.OpenQueue(constants.UpdateAnswerQueueName)
-	if err != nil {
-		panic(err)
-	}
+	queueService := queue.NewService(cfg.QueueConfig, dbredis.GetClient(), dbmysql.GetDB())
+	updateAnswerQueue, updateFlagQueue, answerSimilarityQueue := openQueues(queueService)
 
 	// repositories
+	examRepository := exam.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	questionRepository := question.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	mcqOptionRepository := mcqoption.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	participantRepository := participant.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
 	submissionRepository := submission.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	analyticsRepository := analytics.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	deadLetterRepository := deadletter.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	reconciliationRepository := reconciliation.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	questionBankRepository := questionbank.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient(), questionRepository)
+	questionPoolRepository := questionpool.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
+	receiptRepository := receipt.NewRepository(cfg, dbmysql.GetDB(), dbredis.GetClient())
 
 	// services
-	submissionService := submission.NewService(submissionRepository, dbredis.GetClient(), updateAnswerQueue)
-
-	// consumers
-	updateAnswerConsumer := worker.NewUpdateAnswerQueueConsumer(submissionService)
+	examService := exam.NewService(examRepository)
+	questionService := question.NewService(questionRepository)
+	mcqOptionService := mcqoption.NewService(mcqOptionRepository)
+	participantService := participant.NewService(cfg, participantRepository, examService)
+	submissionService := submission.NewService(submissionRepository, dbredis.GetClient(), updateAnswerQueue, updateFlagQueue)
+	analyticsService := analytics.NewService(cfg, answerSimilarityQueue, analyticsRepository, participantService, questionService, mcqOptionService)
+	examEventService := examevent.NewService(dbredis.GetClient())
+	deadLetterService := deadletter.NewService(deadLetterRepository, map[string]queue.Queue{
+		constants.UpdateAnswerQueueName: updateAnswerQueue,
+		constants.UpdateFlagQueueName:   updateFlagQueue,
+	})
+	reconciliationService := reconciliation.NewService(reconciliationRepository, submissionService, participantService, questionService)
+	questionBankService := questionbank.NewService(questionBankRepository)
+	questionPoolService := questionpool.NewService(questionPoolRepository, questionBankService, questionService)
+	receiptService := receipt.NewService(cfg, receiptRepository, submissionService)
 
 	// routes
 	router := gin.Default()
@@ -172,12 +300,70 @@ func initWorker(cfg *config.Config) {
 	})
 
 	// workers
-	workerService := worker.NewService(
+	workerService := newWorkerService(
 		cfg,
 		updateAnswerQueue,
-		updateAnswerConsumer,
+		updateFlagQueue,
+		answerSimilarityQueue,
+		submissionService,
+		analyticsService,
+		participantService,
+		examEventService,
+		deadLetterService,
+		reconciliationService,
+		questionPoolService,
+		receiptService,
 	)
 	workerService.InitConsumers()
 
 	router.Run(fmt.Sprintf(":%d", cfg.RESTPort))
 }
+
+func openQueues(queueService queue.Service) (updateAnswerQueue, updateFlagQueue, answerSimilarityQueue queue.Queue) {
+	updateAnswerQueue, err := queueService.OpenQueue(constants.UpdateAnswerQueueName)
+	if err != nil {
+		panic(err)
+	}
+	updateFlagQueue, err = queueService.OpenQueue(constants.UpdateFlagQueueName)
+	if err != nil {
+		panic(err)
+	}
+	answerSimilarityQueue, err = queueService.OpenQueue(constants.AnswerSimilarityQueueName)
+	if err != nil {
+		panic(err)
+	}
+	return updateAnswerQueue, updateFlagQueue, answerSimilarityQueue
+}
+
+func newWorkerService(
+	cfg *config.Config,
+	updateAnswerQueue queue.Queue,
+	updateFlagQueue queue.Queue,
+	answerSimilarityQueue queue.Queue,
+	submissionService submission.Service,
+	analyticsService analytics.Service,
+	participantService participant.Service,
+	examEventService examevent.Service,
+	deadLetterService deadletter.Service,
+	reconciliationService reconciliation.Service,
+	questionPoolService questionpool.Service,
+	receiptService receipt.Service,
+) worker.Service {
+	// consumers
+	updateAnswerConsumer := worker.NewUpdateAnswerQueueConsumer(cfg, submissionService, participantService, examEventService, deadLetterService)
+	updateFlagConsumer := worker.NewUpdateFlagQueueConsumer(cfg, submissionService, deadLetterService)
+	answerSimilarityConsumer := worker.NewAnswerSimilarityQueueConsumer(analyticsService)
+	expiredParticipantFinalizer := worker.NewExpiredParticipantFinalizer(participantService, questionPoolService, submissionService, receiptService, analyticsService, examEventService)
+
+	return worker.NewService(
+		cfg,
+		updateAnswerQueue,
+		updateAnswerConsumer,
+		updateFlagQueue,
+		updateFlagConsumer,
+		answerSimilarityQueue,
+		answerSimilarityConsumer,
+		reconciliationService,
+		expiredParticipantFinalizer,
+	)
+}
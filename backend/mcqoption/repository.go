@@ -19,6 +19,9 @@ type Repository interface {
 	GetMcqOptionByID(id uint) (*McqOption, error)
 	UpdateMcqOption(mcqOption *McqOption) error
 	DeleteMcqOptionByID(id uint) error
+
+	GetMcqOptionByIDCacheKey(id uint) string
+	GetMcqOptionByQuestionIDCacheKey(questionID uint) string
 }
 
 type repository struct {
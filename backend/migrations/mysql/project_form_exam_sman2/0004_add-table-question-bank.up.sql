@@ -0,0 +1,34 @@
+CREATE TABLE bank_questions(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    grade VARCHAR(255) NOT NULL DEFAULT '',
+    topic VARCHAR(255) NOT NULL DEFAULT '',
+    difficulty VARCHAR(255) NOT NULL DEFAULT '',
+    data LONGTEXT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    INDEX (subject, grade, topic, difficulty)
+);
+
+CREATE TABLE bank_mcq_options(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    bank_question_id BIGINT NOT NULL,
+    description TEXT,
+    point INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (bank_question_id) REFERENCES bank_questions(id)
+);
+
+ALTER TABLE questions ADD bank_question_id BIGINT DEFAULT NULL;
+ALTER TABLE questions ADD CONSTRAINT FK_questions_bank_question_id FOREIGN KEY (bank_question_id) REFERENCES bank_questions(id);
@@ -0,0 +1,5 @@
+ALTER TABLE questions DROP FOREIGN KEY FK_questions_bank_question_id;
+ALTER TABLE questions DROP COLUMN bank_question_id;
+
+DROP TABLE bank_mcq_options;
+DROP TABLE bank_questions;
@@ -0,0 +1,35 @@
+CREATE TABLE exam_question_pools(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    exam_id BIGINT NOT NULL,
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    grade VARCHAR(255) NOT NULL DEFAULT '',
+    topic VARCHAR(255) NOT NULL DEFAULT '',
+    difficulty VARCHAR(255) NOT NULL DEFAULT '',
+    draw_count INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (exam_id) REFERENCES exams(id)
+);
+
+CREATE TABLE participant_questions(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    participant_id BIGINT NOT NULL,
+    question_id BIGINT NOT NULL,
+    order_number BIGINT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    not_archived BOOLEAN GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (participant_id) REFERENCES participants(id),
+    CONSTRAINT FOREIGN KEY (question_id) REFERENCES questions(id),
+    CONSTRAINT UNIQUE (participant_id, question_id, not_archived)
+);
@@ -0,0 +1,2 @@
+DROP TABLE participant_questions;
+DROP TABLE exam_question_pools;
@@ -0,0 +1,49 @@
+CREATE TABLE answer_key_revisions(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    exam_id BIGINT NOT NULL,
+    question_id BIGINT NOT NULL,
+    reason TEXT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (exam_id) REFERENCES exams(id),
+    CONSTRAINT FOREIGN KEY (question_id) REFERENCES questions(id)
+);
+
+CREATE TABLE answer_key_revision_options(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    answer_key_revision_id BIGINT NOT NULL,
+    mcq_option_id BIGINT NOT NULL,
+    previous_point INT NOT NULL DEFAULT 0,
+    new_point INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (answer_key_revision_id) REFERENCES answer_key_revisions(id),
+    CONSTRAINT FOREIGN KEY (mcq_option_id) REFERENCES mcq_options(id)
+);
+
+CREATE TABLE answer_key_revision_scores(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    answer_key_revision_id BIGINT NOT NULL,
+    participant_id BIGINT NOT NULL,
+    previous_total_point INT NOT NULL DEFAULT 0,
+    new_total_point INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (answer_key_revision_id) REFERENCES answer_key_revisions(id),
+    CONSTRAINT FOREIGN KEY (participant_id) REFERENCES participants(id)
+);
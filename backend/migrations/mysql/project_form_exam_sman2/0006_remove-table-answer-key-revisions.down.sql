@@ -0,0 +1,3 @@
+DROP TABLE answer_key_revision_scores;
+DROP TABLE answer_key_revision_options;
+DROP TABLE answer_key_revisions;
@@ -0,0 +1,5 @@
+ALTER TABLE exams ADD wrong_answer_penalty INT NOT NULL DEFAULT 0;
+ALTER TABLE exams ADD blank_answer_point INT NOT NULL DEFAULT 0;
+ALTER TABLE exams ADD min_question_point INT DEFAULT NULL;
+ALTER TABLE exams ADD min_exam_point INT DEFAULT NULL;
+ALTER TABLE exams ADD max_exam_point INT DEFAULT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE exams DROP COLUMN max_exam_point;
+ALTER TABLE exams DROP COLUMN min_exam_point;
+ALTER TABLE exams DROP COLUMN min_question_point;
+ALTER TABLE exams DROP COLUMN blank_answer_point;
+ALTER TABLE exams DROP COLUMN wrong_answer_penalty;
@@ -0,0 +1,17 @@
+ALTER TABLE exams ADD max_score INT DEFAULT NULL;
+ALTER TABLE exams ADD passing_score DOUBLE DEFAULT NULL;
+
+CREATE TABLE exam_grade_bands(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    exam_id BIGINT NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    min_score DOUBLE NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (exam_id) REFERENCES exams(id)
+);
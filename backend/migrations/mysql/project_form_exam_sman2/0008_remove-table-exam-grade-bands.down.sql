@@ -0,0 +1,4 @@
+DROP TABLE exam_grade_bands;
+
+ALTER TABLE exams DROP COLUMN passing_score;
+ALTER TABLE exams DROP COLUMN max_score;
@@ -0,0 +1,22 @@
+CREATE TABLE answer_similarity_pairs(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    exam_id BIGINT NOT NULL,
+    first_participant_id BIGINT NOT NULL,
+    second_participant_id BIGINT NOT NULL,
+    common_question_count INT NOT NULL DEFAULT 0,
+    identical_answer_count INT NOT NULL DEFAULT 0,
+    shared_wrong_count INT NOT NULL DEFAULT 0,
+    expected_shared_wrong_count DOUBLE NOT NULL DEFAULT 0,
+    score DOUBLE NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (exam_id) REFERENCES exams(id),
+    CONSTRAINT FOREIGN KEY (first_participant_id) REFERENCES participants(id),
+    CONSTRAINT FOREIGN KEY (second_participant_id) REFERENCES participants(id),
+    INDEX (exam_id, score)
+);
@@ -0,0 +1 @@
+DROP TABLE answer_similarity_pairs;
@@ -0,0 +1,20 @@
+ALTER TABLE participant_sessions ADD rejected_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE participant_sessions ADD revoked_at TIMESTAMP NULL DEFAULT NULL;
+
+CREATE TABLE participant_session_audits(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    participant_session_id BIGINT NOT NULL,
+    participant_id BIGINT NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    reason TEXT NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (participant_session_id) REFERENCES participant_sessions(id),
+    CONSTRAINT FOREIGN KEY (participant_id) REFERENCES participants(id),
+    INDEX (participant_id)
+);
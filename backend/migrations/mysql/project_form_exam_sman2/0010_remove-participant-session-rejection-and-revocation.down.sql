@@ -0,0 +1,4 @@
+DROP TABLE participant_session_audits;
+
+ALTER TABLE participant_sessions DROP COLUMN revoked_at;
+ALTER TABLE participant_sessions DROP COLUMN rejected_at;
@@ -0,0 +1,18 @@
+ALTER TABLE participants ADD paused_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE participants ADD paused_seconds BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE participant_pauses(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    participant_id BIGINT NOT NULL,
+    paused_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resumed_at TIMESTAMP NULL DEFAULT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (participant_id) REFERENCES participants(id),
+    INDEX (participant_id, resumed_at)
+);
@@ -0,0 +1,4 @@
+DROP TABLE participant_pauses;
+
+ALTER TABLE participants DROP COLUMN paused_seconds;
+ALTER TABLE participants DROP COLUMN paused_at;
@@ -0,0 +1,19 @@
+ALTER TABLE participants ADD extended_minutes INT NOT NULL DEFAULT 0;
+ALTER TABLE participants ADD time_multiplier DOUBLE NOT NULL DEFAULT 1;
+ALTER TABLE participants ADD accommodation_note VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE participant_time_extensions(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    participant_id BIGINT NOT NULL,
+    minutes INT NOT NULL,
+    reason TEXT NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (participant_id) REFERENCES participants(id),
+    INDEX (participant_id)
+);
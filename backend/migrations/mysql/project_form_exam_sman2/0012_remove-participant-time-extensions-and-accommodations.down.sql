@@ -0,0 +1,5 @@
+DROP TABLE participant_time_extensions;
+
+ALTER TABLE participants DROP COLUMN accommodation_note;
+ALTER TABLE participants DROP COLUMN time_multiplier;
+ALTER TABLE participants DROP COLUMN extended_minutes;
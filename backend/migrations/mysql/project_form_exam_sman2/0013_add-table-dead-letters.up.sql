@@ -0,0 +1,16 @@
+CREATE TABLE dead_letters(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    queue_name VARCHAR(255) NOT NULL,
+    payload TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    replayed_at TIMESTAMP NULL DEFAULT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    INDEX (queue_name, replayed_at)
+);
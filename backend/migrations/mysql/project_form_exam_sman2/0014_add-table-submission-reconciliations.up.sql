@@ -0,0 +1,18 @@
+CREATE TABLE submission_reconciliations(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    exam_id BIGINT DEFAULT NULL,
+    scanned_count INT NOT NULL DEFAULT 0,
+    missing_count INT NOT NULL DEFAULT 0,
+    stale_count INT NOT NULL DEFAULT 0,
+    failed_count INT NOT NULL DEFAULT 0,
+    finished_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (exam_id) REFERENCES exams(id),
+    INDEX (exam_id)
+);
@@ -0,0 +1,19 @@
+CREATE TABLE submission_events(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    participant_id BIGINT NOT NULL,
+    question_id BIGINT NOT NULL,
+    mcq_option_id BIGINT NOT NULL,
+    session_serial VARCHAR(255) NOT NULL DEFAULT '',
+    answered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (participant_id) REFERENCES participants(id),
+    CONSTRAINT FOREIGN KEY (question_id) REFERENCES questions(id),
+    CONSTRAINT FOREIGN KEY (mcq_option_id) REFERENCES mcq_options(id),
+    INDEX (participant_id, answered_at)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE submissions ADD sequence BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE submission_events ADD sequence BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE submission_events ADD idempotency_key VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE submission_events DROP COLUMN idempotency_key;
+ALTER TABLE submission_events DROP COLUMN sequence;
+ALTER TABLE submissions DROP COLUMN sequence;
@@ -0,0 +1,17 @@
+CREATE TABLE submission_flags(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    participant_id BIGINT NOT NULL,
+    question_id BIGINT NOT NULL,
+    is_flagged BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    not_archived BOOLEAN GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (participant_id) REFERENCES participants(id),
+    CONSTRAINT FOREIGN KEY (question_id) REFERENCES questions(id),
+    CONSTRAINT UNIQUE (participant_id, question_id, not_archived)
+);
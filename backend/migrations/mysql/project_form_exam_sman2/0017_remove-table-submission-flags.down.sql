@@ -0,0 +1 @@
+DROP TABLE submission_flags;
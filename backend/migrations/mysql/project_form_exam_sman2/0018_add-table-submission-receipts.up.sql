@@ -0,0 +1,20 @@
+CREATE TABLE submission_receipts(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    participant_id BIGINT NOT NULL,
+    exam_id BIGINT NOT NULL,
+    answer_count INT NOT NULL DEFAULT 0,
+    answer_hash VARCHAR(64) NOT NULL,
+    submitted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    signature VARCHAR(64) NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    not_archived BOOLEAN GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    CONSTRAINT FOREIGN KEY (participant_id) REFERENCES participants(id),
+    CONSTRAINT FOREIGN KEY (exam_id) REFERENCES exams(id),
+    CONSTRAINT UNIQUE (participant_id, not_archived)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE queue_messages(
+    id BIGINT NOT NULL AUTO_INCREMENT,
+
+    queue_name VARCHAR(255) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+
+    CONSTRAINT PK_id PRIMARY KEY (id),
+    INDEX (queue_name, status, id)
+);
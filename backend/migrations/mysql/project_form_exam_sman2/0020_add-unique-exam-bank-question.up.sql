@@ -0,0 +1,2 @@
+ALTER TABLE questions ADD not_archived BOOLEAN GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL;
+ALTER TABLE questions ADD CONSTRAINT UC_exam_id_bank_question_id UNIQUE (exam_id, bank_question_id, not_archived);
@@ -0,0 +1,2 @@
+ALTER TABLE questions DROP INDEX UC_exam_id_bank_question_id;
+ALTER TABLE questions DROP COLUMN not_archived;
@@ -0,0 +1,2 @@
+ALTER TABLE queue_messages ADD taken_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE queue_messages ADD INDEX IDX_queue_name_status_taken_at (queue_name, status, taken_at);
@@ -0,0 +1,2 @@
+ALTER TABLE queue_messages DROP INDEX IDX_queue_name_status_taken_at;
+ALTER TABLE queue_messages DROP COLUMN taken_at;
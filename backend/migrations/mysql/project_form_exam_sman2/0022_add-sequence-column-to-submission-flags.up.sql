@@ -0,0 +1 @@
+ALTER TABLE submission_flags ADD sequence BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE submission_flags DROP COLUMN sequence;
@@ -0,0 +1,11 @@
+DELETE duplicate FROM submission_events duplicate
+JOIN submission_events original
+ON
+    original.participant_id = duplicate.participant_id
+    AND original.question_id = duplicate.question_id
+    AND original.sequence = duplicate.sequence
+    AND original.idempotency_key = duplicate.idempotency_key
+    AND original.id < duplicate.id
+WHERE duplicate.sequence > 0;
+ALTER TABLE submission_events ADD unique_sequence BIGINT GENERATED ALWAYS AS (IF(sequence > 0, sequence, NULL)) VIRTUAL;
+ALTER TABLE submission_events ADD CONSTRAINT UC_participant_id_question_id_sequence_idempotency_key UNIQUE (participant_id, question_id, unique_sequence, idempotency_key);
@@ -0,0 +1,2 @@
+ALTER TABLE submission_events DROP INDEX UC_participant_id_question_id_sequence_idempotency_key;
+ALTER TABLE submission_events DROP COLUMN unique_sequence;
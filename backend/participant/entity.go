@@ -1,6 +1,7 @@
 package participant
 
 import (
+	"math"
 	"time"
 
 	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
@@ -14,16 +15,97 @@ type Participant struct {
 	AllowedDurationMinutes uint
 	StartedAt              *time.Time
 	EndedAt                *time.Time
+	PausedAt               *time.Time // start of the ongoing pause, nil when the clock is running
+	PausedSeconds          uint       // total length of the finished pauses
+	ExtendedMinutes        uint       // extra time granted by proctors after the start
+	TimeMultiplier         float64    // standing accommodation, applied to the allowed duration at start
+	AccommodationNote      string
+}
+
+type ParticipantTimeExtension struct {
+	lib.BaseModel
+	ParticipantID uint
+	Minutes       uint
+	Reason        string
+}
+
+type ParticipantPause struct {
+	lib.BaseModel
+	ParticipantID uint
+	PausedAt      time.Time
+	ResumedAt     *time.Time
+}
+
+// IsSubmitted reports whether the participant has finished the exam, either explicitly or by running out of time.
+func (p *Participant) IsSubmitted() bool {
+	if p.StartedAt == nil {
+		return false
+	}
+	return p.EndedAt != nil || p.IsExpired(time.Now())
+}
+
+func (p *Participant) IsPaused() bool {
+	return p.PausedAt != nil
+}
+
+// PausedDuration is the time excluded from the participant's clock, including the ongoing pause.
+func (p *Participant) PausedDuration(now time.Time) time.Duration {
+	res := time.Duration(p.PausedSeconds) * time.Second
+	if p.PausedAt != nil && now.After(*p.PausedAt) {
+		res += now.Sub(*p.PausedAt)
+	}
+	return res
+}
+
+// TotalDurationMinutes is the allowed duration including the extensions granted so far.
+func (p *Participant) TotalDurationMinutes() uint {
+	return p.AllowedDurationMinutes + p.ExtendedMinutes
+}
+
+// AccommodatedDurationMinutes applies the participant's time multiplier to the duration authorized at start.
+func (p *Participant) AccommodatedDurationMinutes(durationMinutes uint) uint {
+	if p.TimeMultiplier <= 0 {
+		return durationMinutes
+	}
+	return uint(math.Ceil(float64(durationMinutes) * p.TimeMultiplier))
+}
+
+// Deadline is when the participant runs out of time, it keeps moving while the participant is paused.
+// It should only be used once the participant has started.
+func (p *Participant) Deadline(now time.Time) time.Time {
+	return p.StartedAt.Add(time.Duration(p.TotalDurationMinutes())*time.Minute + p.PausedDuration(now))
+}
+
+func (p *Participant) IsExpired(now time.Time) bool {
+	return p.StartedAt != nil && p.Deadline(now).Before(now)
 }
 
 type ParticipantTotalPoint struct {
 	ParticipantID uint
-	TotalPoint    int
+	TotalPoint    int // total point under the exam's scoring policy
+	MaxPoint      int // maximum achievable total point of the questions served to the participant
 }
 
 type ParticipantAnswers struct {
 	ParticipantID uint
 	QuestionID    uint
+	McqOptionID   uint
 	Answer        string
-	Point         int
+	IsAnswered    bool
+	IsCorrect     bool // the chosen option has a positive point
+	Point         int  // point under the exam's scoring policy
+}
+
+type ParticipantProgress struct {
+	ParticipantID uint
+	ServedCount   int
+	AnsweredCount int
+}
+
+type QuestionStatistics struct {
+	QuestionID    uint
+	ServedCount   int
+	AnsweredCount int
+	CorrectCount  int
+	TotalPoint    int
 }
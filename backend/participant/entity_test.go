@@ -0,0 +1,93 @@
+package participant
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParticipantPausedDuration(t *testing.T) {
+	now := time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC)
+	pausedAt := now.Add(-5 * time.Minute)
+	tests := []struct {
+		name        string
+		participant Participant
+		want        time.Duration
+	}{
+		{name: "never paused", want: 0},
+		{name: "finished pauses", participant: Participant{PausedSeconds: 90}, want: 90 * time.Second},
+		{name: "ongoing pause", participant: Participant{PausedSeconds: 90, PausedAt: &pausedAt}, want: 90*time.Second + 5*time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.participant.PausedDuration(now); got != tt.want {
+				t.Fatalf("PausedDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParticipantDeadline(t *testing.T) {
+	startedAt := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	participant := Participant{
+		AllowedDurationMinutes: 60,
+		ExtendedMinutes:        10,
+		PausedSeconds:          120,
+		StartedAt:              &startedAt,
+	}
+
+	now := startedAt.Add(30 * time.Minute)
+	want := startedAt.Add(72 * time.Minute)
+	if got := participant.Deadline(now); !got.Equal(want) {
+		t.Fatalf("Deadline() = %v, want %v", got, want)
+	}
+
+	// the deadline keeps moving during the ongoing pause
+	pausedAt := now
+	participant.PausedAt = &pausedAt
+	later := now.Add(15 * time.Minute)
+	if got := participant.Deadline(later); !got.Equal(want.Add(15 * time.Minute)) {
+		t.Fatalf("Deadline() while paused = %v, want %v", got, want.Add(15*time.Minute))
+	}
+}
+
+func TestParticipantIsExpired(t *testing.T) {
+	startedAt := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	participant := Participant{AllowedDurationMinutes: 60}
+	if participant.IsExpired(startedAt.Add(2 * time.Hour)) {
+		t.Fatal("IsExpired() = true before the start")
+	}
+
+	participant.StartedAt = &startedAt
+	if participant.IsExpired(startedAt.Add(60 * time.Minute)) {
+		t.Fatal("IsExpired() = true at the deadline")
+	}
+	if !participant.IsExpired(startedAt.Add(61 * time.Minute)) {
+		t.Fatal("IsExpired() = false after the deadline")
+	}
+
+	pausedAt := startedAt.Add(30 * time.Minute)
+	participant.PausedAt = &pausedAt
+	if participant.IsExpired(startedAt.Add(61 * time.Minute)) {
+		t.Fatal("IsExpired() = true while paused")
+	}
+}
+
+func TestParticipantAccommodatedDurationMinutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		timeMultiplier float64
+		want           uint
+	}{
+		{name: "no multiplier", timeMultiplier: 0, want: 45},
+		{name: "rounds up", timeMultiplier: 1.5, want: 68},
+		{name: "whole multiplier", timeMultiplier: 2, want: 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			participant := Participant{TimeMultiplier: tt.timeMultiplier}
+			if got := participant.AccommodatedDurationMinutes(45); got != tt.want {
+				t.Fatalf("AccommodatedDurationMinutes() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
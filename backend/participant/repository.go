@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
@@ -21,14 +22,44 @@ type Repository interface {
 	GetParticipantByExamIDAndName(examID uint, name string) (*Participant, error)
 	UpdateParticipant(participant *Participant) error
 	DeleteParticipantByID(id uint) error
+	GetRunningParticipants() ([]*Participant, error)
+	EndParticipant(id uint, endedAt time.Time) error
+	PauseParticipant(id uint) error
+	ResumeParticipant(id uint) error
+	ExtendParticipantTime(id uint, minutes uint, reason string) error
+	UpdateParticipantAccommodation(id uint, timeMultiplier float64, note string) error
 
 	GetParticipantTotalPointsByExamID(examID uint) ([]*ParticipantTotalPoint, error)
+	GetParticipantTotalPointsByExamIDInTx(tx *gorm.DB, examID uint) ([]*ParticipantTotalPoint, error)
 	GetParticipantsAnswersByExamID(examID uint) ([]*ParticipantAnswers, error)
+	GetQuestionStatisticsByExamID(examID uint) ([]*QuestionStatistics, error)
+	GetParticipantProgressesByExamID(examID uint) ([]*ParticipantProgress, error)
 
 	GetParticipantByIDCacheKey(id uint) string
 	GetParticipantByExamIDAndNameCacheKey(examID uint, name string) string
 }
 
+// servedQuestionCondition keeps only the questions served to participant p, which is every question of the exam
+// unless the participant drew their own set from the exam question pools.
+const servedQuestionCondition = `(
+			NOT EXISTS (SELECT 1 FROM participant_questions pq WHERE pq.participant_id = p.id AND pq.deleted_at IS NULL)
+			OR EXISTS (SELECT 1 FROM participant_questions pq WHERE pq.participant_id = p.id AND pq.question_id = q.id AND pq.deleted_at IS NULL)
+		)`
+
+// rawQuestionPointExpression is the point of question q for participant p under the scoring policy of exam e,
+// given the chosen mcq option m (NULL when the question is not answered).
+const rawQuestionPointExpression = `(
+			CASE
+				WHEN p.started_at IS NULL OR q.id IS NULL THEN 0
+				WHEN m.id IS NULL THEN e.blank_answer_point
+				WHEN m.point > 0 THEN m.point
+				ELSE m.point - e.wrong_answer_penalty
+			END
+		)`
+
+// questionPointExpression is rawQuestionPointExpression floored by the exam's minimum point per question.
+const questionPointExpression = `GREATEST(` + rawQuestionPointExpression + `, COALESCE(e.min_question_point, ` + rawQuestionPointExpression + `))`
+
 type repository struct {
 	cfg   *config.Config
 	db    *gorm.DB
@@ -146,58 +177,343 @@ func (r *repository) DeleteParticipantByID(id uint) error {
 	return nil
 }
 
+// GetRunningParticipants returns the started participants which are neither submitted nor paused, regardless of their deadline.
+func (r *repository) GetRunningParticipants() ([]*Participant, error) {
+	var res []*Participant
+	err := r.db.Where("started_at IS NOT NULL AND ended_at IS NULL AND paused_at IS NULL").Find(&res).Error
+	return res, err
+}
+
+func (r *repository) EndParticipant(id uint, endedAt time.Time) error {
+	currentData, err := r.GetParticipantByID(id)
+	if err != nil {
+		return err
+	}
+
+	// the participant may have submitted in the meantime, which is kept as is
+	res := r.db.Model(&Participant{}).Where("id = ? AND ended_at IS NULL", id).Update("ended_at", endedAt)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return lib.ErrParticipantAlreadyEnded
+	}
+
+	r.cache.Del(context.Background(), r.GetParticipantByIDCacheKey(currentData.ID))
+	r.cache.Del(context.Background(), r.GetParticipantByExamIDAndNameCacheKey(currentData.ExamID, currentData.Name))
+	return nil
+}
+
+func (r *repository) PauseParticipant(id uint) error {
+	currentData, err := r.GetParticipantByID(id)
+	if err != nil {
+		return err
+	}
+	if currentData.PausedAt != nil {
+		return lib.ErrParticipantAlreadyPaused
+	}
+
+	currentTime := time.Now()
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Participant{}).Where("id = ? AND paused_at IS NULL", id).Update("paused_at", currentTime)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return lib.ErrParticipantAlreadyPaused
+		}
+		return tx.Create(&ParticipantPause{
+			ParticipantID: id,
+			PausedAt:      currentTime,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	r.cache.Del(context.Background(), r.GetParticipantByIDCacheKey(currentData.ID))
+	r.cache.Del(context.Background(), r.GetParticipantByExamIDAndNameCacheKey(currentData.ExamID, currentData.Name))
+	return nil
+}
+
+func (r *repository) ResumeParticipant(id uint) error {
+	currentData, err := r.GetParticipantByID(id)
+	if err != nil {
+		return err
+	}
+	if currentData.PausedAt == nil {
+		return lib.ErrParticipantNotPaused
+	}
+
+	currentTime := time.Now()
+	pausedSeconds := uint(currentTime.Sub(*currentData.PausedAt).Seconds())
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Participant{}).Where("id = ? AND paused_at IS NOT NULL", id).Updates(
+			map[string]interface{}{
+				"paused_at":      nil,
+				"paused_seconds": gorm.Expr("paused_seconds + ?", pausedSeconds),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return lib.ErrParticipantNotPaused
+		}
+		return tx.Model(&ParticipantPause{}).Where("participant_id = ? AND resumed_at IS NULL", id).Update("resumed_at", currentTime).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	r.cache.Del(context.Background(), r.GetParticipantByIDCacheKey(currentData.ID))
+	r.cache.Del(context.Background(), r.GetParticipantByExamIDAndNameCacheKey(currentData.ExamID, currentData.Name))
+	return nil
+}
+
+func (r *repository) ExtendParticipantTime(id uint, minutes uint, reason string) error {
+	currentData, err := r.GetParticipantByID(id)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Participant{}).Where("id = ?", id).Update("extended_minutes", gorm.Expr("extended_minutes + ?", minutes)).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&ParticipantTimeExtension{
+			ParticipantID: id,
+			Minutes:       minutes,
+			Reason:        reason,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	r.cache.Del(context.Background(), r.GetParticipantByIDCacheKey(currentData.ID))
+	r.cache.Del(context.Background(), r.GetParticipantByExamIDAndNameCacheKey(currentData.ExamID, currentData.Name))
+	return nil
+}
+
+func (r *repository) UpdateParticipantAccommodation(id uint, timeMultiplier float64, note string) error {
+	currentData, err := r.GetParticipantByID(id)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.Model(&Participant{}).Where("id = ?", id).Updates(
+		map[string]interface{}{
+			"time_multiplier":    timeMultiplier,
+			"accommodation_note": note,
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	r.cache.Del(context.Background(), r.GetParticipantByIDCacheKey(currentData.ID))
+	r.cache.Del(context.Background(), r.GetParticipantByExamIDAndNameCacheKey(currentData.ExamID, currentData.Name))
+	return nil
+}
+
 func (r *repository) GetParticipantTotalPointsByExamID(examID uint) ([]*ParticipantTotalPoint, error) {
+	return r.GetParticipantTotalPointsByExamIDInTx(r.db, examID)
+}
+
+// GetParticipantTotalPointsByExamIDInTx reads the total points within the given transaction, so they can be compared around a change made in it.
+func (r *repository) GetParticipantTotalPointsByExamIDInTx(tx *gorm.DB, examID uint) ([]*ParticipantTotalPoint, error) {
 	var res []*ParticipantTotalPoint
+	err := tx.Raw(`
+		SELECT
+			t.participant_id,
+			LEAST(
+				GREATEST(t.total_point, COALESCE(t.min_exam_point, t.total_point)),
+				COALESCE(t.max_exam_point, t.total_point)
+			) AS total_point,
+			COALESCE(t.max_score, LEAST(t.max_point, COALESCE(t.max_exam_point, t.max_point))) AS max_point
+		FROM (
+			SELECT
+				p.id AS participant_id,
+				e.min_exam_point,
+				e.max_exam_point,
+				e.max_score,
+				COALESCE(SUM(`+questionPointExpression+`), 0) AS total_point,
+				COALESCE(SUM(
+					CASE
+						WHEN q.id IS NULL THEN 0
+						ELSE GREATEST(COALESCE(qm.max_point, 0), e.blank_answer_point)
+					END
+				), 0) AS max_point
+			FROM
+				participants p
+			JOIN
+				exams e
+			ON
+				e.id = p.exam_id
+			LEFT JOIN
+				questions q
+			ON
+				q.exam_id = p.exam_id
+				AND q.deleted_at IS NULL
+				AND `+servedQuestionCondition+`
+			LEFT JOIN (
+				SELECT
+					question_id,
+					MAX(point) AS max_point
+				FROM
+					mcq_options
+				WHERE
+					deleted_at IS NULL
+				GROUP BY
+					question_id
+			) qm
+			ON
+				qm.question_id = q.id
+			LEFT JOIN
+				submissions s
+			ON
+				s.participant_id = p.id
+				AND s.question_id = q.id
+				AND s.deleted_at IS NULL
+			LEFT JOIN
+				mcq_options m
+			ON
+				s.mcq_option_id = m.id
+				AND m.deleted_at IS NULL
+			WHERE
+				p.exam_id = ?
+				AND p.deleted_at IS NULL
+			GROUP BY
+				p.id, e.min_exam_point, e.max_exam_point, e.max_score
+		) t
+		ORDER BY
+			t.participant_id ASC;
+	`, examID).Scan(&res).Error
+	return res, err
+}
+
+func (r *repository) GetParticipantsAnswersByExamID(examID uint) ([]*ParticipantAnswers, error) {
+	// one row for every question served to a started participant, including the unanswered ones
+	var res []*ParticipantAnswers
 	err := r.db.Raw(`
 		SELECT
-		    p.id AS participant_id,
-		    COALESCE(SUM(m.point), 0) AS total_point
+			p.id AS participant_id,
+			q.id AS question_id,
+			COALESCE(m.id, 0) AS mcq_option_id,
+			COALESCE(m.description, '') AS answer,
+			m.id IS NOT NULL AS is_answered,
+			COALESCE(m.point > 0, FALSE) AS is_correct,
+			`+questionPointExpression+` AS point
 		FROM
-		    participants p
+			participants p
+		JOIN
+			exams e
+		ON
+			e.id = p.exam_id
+		JOIN
+			questions q
+		ON
+			q.exam_id = p.exam_id
+			AND q.deleted_at IS NULL
+			AND `+servedQuestionCondition+`
 		LEFT JOIN
-		    submissions s
+			submissions s
 		ON
-		    p.id = s.participant_id
+			s.participant_id = p.id
+			AND s.question_id = q.id
+			AND s.deleted_at IS NULL
 		LEFT JOIN
-		    mcq_options m
+			mcq_options m
 		ON
-		    s.mcq_option_id = m.id
+			s.mcq_option_id = m.id
+			AND m.deleted_at IS NULL
 		WHERE
-		    p.exam_id = ?
+			p.exam_id = ?
+			AND p.started_at IS NOT NULL
 			AND p.deleted_at IS NULL
+		ORDER BY
+			p.id ASC, q.order_number ASC;
+	`, examID).Scan(&res).Error
+	return res, err
+}
+
+func (r *repository) GetQuestionStatisticsByExamID(examID uint) ([]*QuestionStatistics, error) {
+	// participants who drew their own question set only count towards the questions they were served
+	var res []*QuestionStatistics
+	err := r.db.Raw(`
+		SELECT
+			q.id AS question_id,
+			COUNT(DISTINCT p.id) AS served_count,
+			COUNT(DISTINCT s.participant_id) AS answered_count,
+			COUNT(DISTINCT CASE WHEN m.point > 0 THEN s.participant_id END) AS correct_count,
+			COALESCE(SUM(`+questionPointExpression+`), 0) AS total_point
+		FROM
+			questions q
+		JOIN
+			exams e
+		ON
+			e.id = q.exam_id
+		JOIN
+			participants p
+		ON
+			p.exam_id = q.exam_id
+			AND p.started_at IS NOT NULL
+			AND p.deleted_at IS NULL
+		LEFT JOIN
+			submissions s
+		ON
+			s.participant_id = p.id
+			AND s.question_id = q.id
 			AND s.deleted_at IS NULL
+		LEFT JOIN
+			mcq_options m
+		ON
+			s.mcq_option_id = m.id
 			AND m.deleted_at IS NULL
+		WHERE
+			q.exam_id = ?
+			AND q.deleted_at IS NULL
+			AND `+servedQuestionCondition+`
 		GROUP BY
-		    1
+			q.id, q.order_number
 		ORDER BY
-		    p.id ASC;
+			q.order_number ASC;
 	`, examID).Scan(&res).Error
 	return res, err
 }
 
-func (r *repository) GetParticipantsAnswersByExamID(examID uint) ([]*ParticipantAnswers, error) {
-	var res []*ParticipantAnswers
+func (r *repository) GetParticipantProgressesByExamID(examID uint) ([]*ParticipantProgress, error) {
+	// answers are counted from the database, so they lag behind the update answer queue by a little
+	var res []*ParticipantProgress
 	err := r.db.Raw(`
 		SELECT
 			p.id AS participant_id,
-			s.question_id AS question_id,
-			m.description AS answer,
-			m.point
+			COUNT(q.id) AS served_count,
+			COUNT(m.id) AS answered_count
 		FROM
-		    participants p
+			participants p
 		LEFT JOIN
-		    submissions s
+			questions q
 		ON
-			p.id = s.participant_id
+			q.exam_id = p.exam_id
+			AND q.deleted_at IS NULL
+			AND `+servedQuestionCondition+`
 		LEFT JOIN
-		    mcq_options m
+			submissions s
 		ON
-		    s.mcq_option_id = m.id
-		WHERE
-		    p.exam_id = ?
-			AND p.deleted_at IS NULL
+			s.participant_id = p.id
+			AND s.question_id = q.id
 			AND s.deleted_at IS NULL
+		LEFT JOIN
+			mcq_options m
+		ON
+			s.mcq_option_id = m.id
 			AND m.deleted_at IS NULL
+		WHERE
+			p.exam_id = ?
+			AND p.deleted_at IS NULL
+		GROUP BY
+			p.id
 		ORDER BY
 			p.id ASC;
 	`, examID).Scan(&res).Error
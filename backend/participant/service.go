@@ -17,10 +17,18 @@ type Service interface {
 	GetParticipantByID(id uint) (*Participant, error)
 	UpdateParticipant(participant *Participant) error
 	DeleteParticipantByID(id uint) error
+	GetExpiredParticipants(now time.Time) ([]*Participant, error)
+	EndParticipant(id uint, endedAt time.Time) error
+	PauseParticipant(id uint) error
+	ResumeParticipant(id uint) error
+	ExtendParticipantTime(id uint, minutes uint, reason string) error
+	UpdateParticipantAccommodation(id uint, timeMultiplier float64, note string) error
 	GetParticipantByExamIDAndName(examID uint, name string) (*Participant, error)
 
 	GetParticipantTotalPointsByExamID(examID uint) ([]*ParticipantTotalPoint, error)
 	GetParticipantsAnswersByExamID(examID uint) ([]*ParticipantAnswers, error)
+	GetQuestionStatisticsByExamID(examID uint) ([]*QuestionStatistics, error)
+	GetParticipantProgressesByExamID(examID uint) ([]*ParticipantProgress, error)
 
 	GenerateToken(examSerial string, participantID uint, sessionSerial string) string
 	VerifyToken(token *jwt.Token) (interface{}, error)
@@ -102,6 +110,82 @@ func (s *service) DeleteParticipantByID(id uint) error {
 	return nil
 }
 
+// GetExpiredParticipants returns the participants which ran out of time but are not submitted yet.
+func (s *service) GetExpiredParticipants(now time.Time) ([]*Participant, error) {
+	participants, err := s.participantRepository.GetRunningParticipants()
+	if err != nil {
+		log.Println("[participant][service][GetExpiredParticipants] failed to get running participants:", err.Error())
+		return nil, lib.ErrFailedToGetParticipants
+	}
+	res := []*Participant{}
+	for _, p := range participants {
+		if p.IsExpired(now) {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+
+func (s *service) EndParticipant(id uint, endedAt time.Time) error {
+	err := s.participantRepository.EndParticipant(id, endedAt)
+	if err != nil {
+		log.Println("[participant][service][EndParticipant] failed to end participant:", err.Error())
+		if errors.Is(err, lib.ErrParticipantNotFound) || errors.Is(err, lib.ErrParticipantAlreadyEnded) {
+			return err
+		}
+		return lib.ErrFailedToEndParticipant
+	}
+	return nil
+}
+
+func (s *service) PauseParticipant(id uint) error {
+	err := s.participantRepository.PauseParticipant(id)
+	if err != nil {
+		log.Println("[participant][service][PauseParticipant] failed to pause participant:", err.Error())
+		if errors.Is(err, lib.ErrParticipantNotFound) || errors.Is(err, lib.ErrParticipantAlreadyPaused) {
+			return err
+		}
+		return lib.ErrFailedToPauseParticipant
+	}
+	return nil
+}
+
+func (s *service) ResumeParticipant(id uint) error {
+	err := s.participantRepository.ResumeParticipant(id)
+	if err != nil {
+		log.Println("[participant][service][ResumeParticipant] failed to resume participant:", err.Error())
+		if errors.Is(err, lib.ErrParticipantNotFound) || errors.Is(err, lib.ErrParticipantNotPaused) {
+			return err
+		}
+		return lib.ErrFailedToResumeParticipant
+	}
+	return nil
+}
+
+func (s *service) ExtendParticipantTime(id uint, minutes uint, reason string) error {
+	err := s.participantRepository.ExtendParticipantTime(id, minutes, reason)
+	if err != nil {
+		log.Println("[participant][service][ExtendParticipantTime] failed to extend participant time:", err.Error())
+		if errors.Is(err, lib.ErrParticipantNotFound) {
+			return err
+		}
+		return lib.ErrFailedToExtendParticipantTime
+	}
+	return nil
+}
+
+func (s *service) UpdateParticipantAccommodation(id uint, timeMultiplier float64, note string) error {
+	err := s.participantRepository.UpdateParticipantAccommodation(id, timeMultiplier, note)
+	if err != nil {
+		log.Println("[participant][service][UpdateParticipantAccommodation] failed to update participant accommodation:", err.Error())
+		if errors.Is(err, lib.ErrParticipantNotFound) {
+			return err
+		}
+		return lib.ErrFailedToUpdateParticipantAccommodation
+	}
+	return nil
+}
+
 func (s *service) GetParticipantByExamIDAndName(examID uint, name string) (*Participant, error) {
 	res, err := s.participantRepository.GetParticipantByExamIDAndName(examID, name)
 	if err != nil {
@@ -132,6 +216,24 @@ func (s *service) GetParticipantsAnswersByExamID(examID uint) ([]*ParticipantAns
 	return res, nil
 }
 
+func (s *service) GetQuestionStatisticsByExamID(examID uint) ([]*QuestionStatistics, error) {
+	res, err := s.participantRepository.GetQuestionStatisticsByExamID(examID)
+	if err != nil {
+		log.Println("[participant][service][GetQuestionStatisticsByExamID] failed to get question statistics by exam id:", err.Error())
+		return nil, lib.ErrFailedToGetQuestionStatistics
+	}
+	return res, nil
+}
+
+func (s *service) GetParticipantProgressesByExamID(examID uint) ([]*ParticipantProgress, error) {
+	res, err := s.participantRepository.GetParticipantProgressesByExamID(examID)
+	if err != nil {
+		log.Println("[participant][service][GetParticipantProgressesByExamID] failed to get participant progresses by exam id:", err.Error())
+		return nil, lib.ErrFailedToGetParticipantProgresses
+	}
+	return res, nil
+}
+
 func (s *service) GenerateToken(examSerial string, participantID uint, sessionSerial string) string {
 	claims := lib.ExamTokenJWTClaims{
 		StandardClaims: jwt.StandardClaims{
@@ -1,6 +1,15 @@
 package participantsession
 
-import "github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+import (
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+const (
+	ActionReject = "reject"
+	ActionRevoke = "revoke"
+)
 
 type ParticipantSession struct {
 	lib.BaseModel
@@ -8,4 +17,15 @@ type ParticipantSession struct {
 	Serial        string
 	ParticipantID uint
 	IsAuthorized  bool
+	RejectedAt    *time.Time // a rejected session can never be authorized
+	RevokedAt     *time.Time // a revoked session was authorized, but can no longer be used
+}
+
+type ParticipantSessionAudit struct {
+	lib.BaseModel
+
+	ParticipantSessionID uint
+	ParticipantID        uint
+	Action               string
+	Reason               string
 }
@@ -19,7 +19,11 @@ type Repository interface {
 	CreateParticipantSession(participantSession *ParticipantSession) (*ParticipantSession, error)
 	GetParticipantSessionBySerial(serial string) (*ParticipantSession, error)
 	GetLatestAuthorizedParticipantSessionByParticipantID(participantID uint) (*ParticipantSession, error)
+	GetPendingParticipantSessionsByExamID(examID uint) ([]*ParticipantSession, error)
 	AuthorizeSession(serial string, durationMinutes uint) error
+	RejectSession(serial string, reason string) error
+	RevokeSession(serial string, reason string) error
+	GetParticipantSessionAuditsByExamID(examID uint) ([]*ParticipantSessionAudit, error)
 }
 
 type repository struct {
@@ -84,7 +88,7 @@ func (r *repository) GetLatestAuthorizedParticipantSessionByParticipantID(partic
 		}
 	}
 
-	err = r.db.Where("participant_id = ? AND is_authorized AND not_archived", participantID).Order("updated_at DESC").First(&participantSession).Error
+	err = r.db.Where("participant_id = ? AND is_authorized AND revoked_at IS NULL AND not_archived", participantID).Order("updated_at DESC").First(&participantSession).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.cache.Set(context.Background(), cacheKey, []byte(constants.None), r.cfg.CacheTTL)
@@ -97,8 +101,35 @@ func (r *repository) GetLatestAuthorizedParticipantSessionByParticipantID(partic
 	return &participantSession, nil
 }
 
+func (r *repository) GetPendingParticipantSessionsByExamID(examID uint) ([]*ParticipantSession, error) {
+	// a session is pending when it is not authorized and was requested after the participant's latest authorized session
+	var res []*ParticipantSession
+	err := r.db.Raw(`
+		SELECT
+			ps.*
+		FROM
+			participant_sessions ps
+		JOIN
+			participants p
+		ON
+			p.id = ps.participant_id
+			AND p.deleted_at IS NULL
+		WHERE
+			p.exam_id = ?
+			AND NOT ps.is_authorized
+			AND ps.rejected_at IS NULL
+			AND ps.deleted_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM participant_sessions a
+				WHERE a.participant_id = ps.participant_id AND a.is_authorized AND a.revoked_at IS NULL AND a.deleted_at IS NULL AND a.updated_at >= ps.created_at
+			)
+		ORDER BY
+			ps.created_at ASC;
+	`, examID).Scan(&res).Error
+	return res, err
+}
+
 func (r *repository) AuthorizeSession(serial string, durationMinutes uint) error {
-	var startExam bool
 	currentData, err := r.GetParticipantSessionBySerial(serial)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -107,18 +138,21 @@ func (r *repository) AuthorizeSession(serial string, durationMinutes uint) error
 			return err
 		}
 	}
+	if currentData.RejectedAt != nil {
+		return lib.ErrSessionRejected
+	}
+	if currentData.RevokedAt != nil {
+		return lib.ErrSessionRevoked
+	}
 	currentParticipant, err := r.participantRepository.GetParticipantByID(currentData.ParticipantID)
-
-	// if there is no authorized session previously, then this function should update participant's start time and duration
-	_, err = r.GetLatestAuthorizedParticipantSessionByParticipantID(currentData.ParticipantID)
 	if err != nil {
-		if errors.Is(err, lib.ErrParticipantSessionNotFound) {
-			startExam = true
-		} else {
-			return err
-		}
+		return err
 	}
 
+	// if the participant has never been authorized, then this function should update participant's start time and duration.
+	// revoked sessions still count, so authorizing a new device does not restart the clock
+	startExam := currentParticipant.StartedAt == nil
+
 	err = r.db.Transaction(func(tx *gorm.DB) error {
 		err := tx.Model(&ParticipantSession{}).Where("serial = ?", serial).Update("is_authorized", true).Error
 		if err != nil {
@@ -128,7 +162,7 @@ func (r *repository) AuthorizeSession(serial string, durationMinutes uint) error
 			err = tx.Table("participants").Where("id = ?", currentData.ParticipantID).Updates(
 				map[string]interface{}{
 					"started_at":               time.Now(),
-					"allowed_duration_minutes": durationMinutes,
+					"allowed_duration_minutes": currentParticipant.AccommodatedDurationMinutes(durationMinutes),
 				}).Error
 		}
 		return nil
@@ -143,6 +177,83 @@ func (r *repository) AuthorizeSession(serial string, durationMinutes uint) error
 	return err
 }
 
+func (r *repository) RejectSession(serial string, reason string) error {
+	currentData, err := r.GetParticipantSessionBySerial(serial)
+	if err != nil {
+		return err
+	}
+	if currentData.IsAuthorized || currentData.RejectedAt != nil {
+		return lib.ErrParticipantSessionNotFound
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&ParticipantSession{}).Where("id = ?", currentData.ID).Update("rejected_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&ParticipantSessionAudit{
+			ParticipantSessionID: currentData.ID,
+			ParticipantID:        currentData.ParticipantID,
+			Action:               ActionReject,
+			Reason:               reason,
+		}).Error
+	})
+
+	if err == nil {
+		r.cache.Del(context.Background(), r.GetParticipantSessionBySerialCacheKey(currentData.Serial))
+	}
+	return err
+}
+
+func (r *repository) RevokeSession(serial string, reason string) error {
+	currentData, err := r.GetParticipantSessionBySerial(serial)
+	if err != nil {
+		return err
+	}
+	if !currentData.IsAuthorized || currentData.RevokedAt != nil {
+		return lib.ErrParticipantSessionNotFound
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&ParticipantSession{}).Where("id = ?", currentData.ID).Update("revoked_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&ParticipantSessionAudit{
+			ParticipantSessionID: currentData.ID,
+			ParticipantID:        currentData.ParticipantID,
+			Action:               ActionRevoke,
+			Reason:               reason,
+		}).Error
+	})
+
+	if err == nil {
+		r.cache.Del(context.Background(), r.GetParticipantSessionBySerialCacheKey(currentData.Serial))
+		r.cache.Del(context.Background(), r.GetLatestAuthorizedParticipantSessionByParticipantIDCacheKey(currentData.ParticipantID))
+	}
+	return err
+}
+
+func (r *repository) GetParticipantSessionAuditsByExamID(examID uint) ([]*ParticipantSessionAudit, error) {
+	var res []*ParticipantSessionAudit
+	err := r.db.Raw(`
+		SELECT
+			psa.*
+		FROM
+			participant_session_audits psa
+		JOIN
+			participants p
+		ON
+			p.id = psa.participant_id
+		WHERE
+			p.exam_id = ?
+			AND psa.deleted_at IS NULL
+		ORDER BY
+			psa.created_at DESC, psa.id DESC;
+	`, examID).Scan(&res).Error
+	return res, err
+}
+
 func (r *repository) GetParticipantSessionBySerialCacheKey(serial string) string {
 	return fmt.Sprintf("participantSession:serial:%s", serial)
 }
@@ -12,7 +12,11 @@ type Service interface {
 	CreateParticipantSession(participantSession *ParticipantSession) (*ParticipantSession, error)
 	GetParticipantSessionBySerial(serial string) (*ParticipantSession, error)
 	GetLatestAuthorizedParticipantSessionByParticipantID(participantID uint) (*ParticipantSession, error)
+	GetPendingParticipantSessionsByExamID(examID uint) ([]*ParticipantSession, error)
 	AuthorizeSession(serial string, durationMinutes uint) error
+	RejectSession(serial string, reason string) error
+	RevokeSession(serial string, reason string) error
+	GetParticipantSessionAuditsByExamID(examID uint) ([]*ParticipantSessionAudit, error)
 }
 
 type service struct {
@@ -65,11 +69,56 @@ func (s *service) GetLatestAuthorizedParticipantSessionByParticipantID(participa
 	return res, nil
 }
 
+func (s *service) GetPendingParticipantSessionsByExamID(examID uint) ([]*ParticipantSession, error) {
+	res, err := s.participantSessionRepository.GetPendingParticipantSessionsByExamID(examID)
+	if err != nil {
+		log.Println("[participantsession][service][GetPendingParticipantSessionsByExamID] failed to get pending participant sessions by exam id:", err.Error())
+		return nil, lib.ErrFailedToGetPendingParticipantSessions
+	}
+	return res, nil
+}
+
 func (s *service) AuthorizeSession(serial string, durationMinutes uint) error {
 	err := s.participantSessionRepository.AuthorizeSession(serial, durationMinutes)
 	if err != nil {
 		log.Println("[participantsession][service][AuthorizeSession] failed to authorize session:", err.Error())
+		if errors.Is(err, lib.ErrSessionRejected) || errors.Is(err, lib.ErrSessionRevoked) {
+			return err
+		}
 		return lib.ErrFailedToAuthorizeParticipantSession
 	}
 	return nil
 }
+
+func (s *service) RejectSession(serial string, reason string) error {
+	err := s.participantSessionRepository.RejectSession(serial, reason)
+	if err != nil {
+		log.Println("[participantsession][service][RejectSession] failed to reject session:", err.Error())
+		if errors.Is(err, lib.ErrParticipantSessionNotFound) {
+			return err
+		}
+		return lib.ErrFailedToRejectParticipantSession
+	}
+	return nil
+}
+
+func (s *service) RevokeSession(serial string, reason string) error {
+	err := s.participantSessionRepository.RevokeSession(serial, reason)
+	if err != nil {
+		log.Println("[participantsession][service][RevokeSession] failed to revoke session:", err.Error())
+		if errors.Is(err, lib.ErrParticipantSessionNotFound) {
+			return err
+		}
+		return lib.ErrFailedToRevokeParticipantSession
+	}
+	return nil
+}
+
+func (s *service) GetParticipantSessionAuditsByExamID(examID uint) ([]*ParticipantSessionAudit, error) {
+	res, err := s.participantSessionRepository.GetParticipantSessionAuditsByExamID(examID)
+	if err != nil {
+		log.Println("[participantsession][service][GetParticipantSessionAuditsByExamID] failed to get participant session audits by exam id:", err.Error())
+		return nil, lib.ErrFailedToGetParticipantSessionAudits
+	}
+	return res, nil
+}
@@ -8,9 +8,10 @@ import (
 
 type Question struct {
 	lib.BaseModel
-	ExamID      uint
-	OrderNumber uint
-	Data        string
+	ExamID         uint
+	OrderNumber    uint
+	Data           string
+	BankQuestionID *uint // set when the question is a snapshot of a question bank item
 }
 
 type GetQuestionsFilter struct {
@@ -21,7 +21,11 @@ type Repository interface {
 	GetQuestions(pagination *lib.QueryPagination, filter *GetQuestionsFilter) ([]*Question, error)
 	GetQuestionsIDByExamID(examID uint) ([]*Question, error)
 	UpdateQuestionDataByID(question *Question) error
+	ReorderQuestions(examID uint, questionIDs []uint) error
 	DeleteQuestionByID(id uint) error
+
+	GetQuestionByIDCacheKey(id uint) string
+	GetQuestionsIDByExamIDCacheKey(examID uint) string
 }
 
 type repository struct {
@@ -131,6 +135,47 @@ func (r *repository) UpdateQuestionDataByID(question *Question) error {
 	return err
 }
 
+// ReorderQuestions sets order_number following the position in questionIDs, which must hold every question of the exam exactly once.
+func (r *repository) ReorderQuestions(examID uint, questionIDs []uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var questions []*Question
+		if err := tx.Select("id").Where("exam_id = ?", examID).Find(&questions).Error; err != nil {
+			return err
+		}
+		if len(questions) != len(questionIDs) {
+			return lib.ErrInvalidQuestionOrder
+		}
+
+		isExamQuestion := map[uint]bool{}
+		for _, q := range questions {
+			isExamQuestion[q.ID] = true
+		}
+		for _, id := range questionIDs {
+			if !isExamQuestion[id] {
+				return lib.ErrInvalidQuestionOrder
+			}
+			// also rejects duplicated ids
+			delete(isExamQuestion, id)
+		}
+
+		for i, id := range questionIDs {
+			if err := tx.Model(&Question{}).Where("id = ?", id).Update(constants.OrderNumber, i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range questionIDs {
+		r.cache.Del(context.Background(), r.GetQuestionByIDCacheKey(id))
+	}
+	r.cache.Del(context.Background(), r.GetQuestionsIDByExamIDCacheKey(examID))
+	return nil
+}
+
 func (r *repository) DeleteQuestionByID(id uint) error {
 	currentData, err := r.GetQuestionByID(id)
 	if err != nil {
@@ -14,6 +14,7 @@ type Service interface {
 	GetQuestions(pagination *lib.QueryPagination, filter *GetQuestionsFilter) ([]*Question, error)
 	GetQuestionsIDByExamID(examID uint) ([]*Question, error)
 	UpdateQuestion(question *Question) error
+	ReorderQuestions(examID uint, questionIDs []uint) error
 	DeleteQuestionByID(id uint) error
 }
 
@@ -89,6 +90,18 @@ func (s *service) UpdateQuestion(question *Question) error {
 	return nil
 }
 
+func (s *service) ReorderQuestions(examID uint, questionIDs []uint) error {
+	err := s.questionRepository.ReorderQuestions(examID, questionIDs)
+	if err != nil {
+		log.Println("[question][service][ReorderQuestions] failed to reorder questions:", err.Error())
+		if errors.Is(err, lib.ErrInvalidQuestionOrder) {
+			return err
+		}
+		return lib.ErrFailedToReorderQuestions
+	}
+	return nil
+}
+
 func (s *service) DeleteQuestionByID(id uint) error {
 	err := s.questionRepository.DeleteQuestionByID(id)
 	if err != nil {
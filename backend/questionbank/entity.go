@@ -0,0 +1,49 @@
+package questionbank
+
+import (
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"gorm.io/gorm"
+)
+
+type BankQuestion struct {
+	lib.BaseModel
+	Subject    string
+	Grade      string
+	Topic      string
+	Difficulty string
+	Data       string
+}
+
+type BankMcqOption struct {
+	lib.BaseModel
+	BankQuestionID uint
+	Description    string
+	Point          int
+}
+
+type GetBankQuestionsFilter struct {
+	SubjectEqualsTo    *lib.QueryFiltersEqualToString `json:"subject_equals_to"`
+	GradeEqualsTo      *lib.QueryFiltersEqualToString `json:"grade_equals_to"`
+	TopicEqualsTo      *lib.QueryFiltersEqualToString `json:"topic_equals_to"`
+	DifficultyEqualsTo *lib.QueryFiltersEqualToString `json:"difficulty_equals_to"`
+}
+
+func (f *GetBankQuestionsFilter) Scope() []func(db *gorm.DB) *gorm.DB {
+	scopes := []func(db *gorm.DB) *gorm.DB{}
+
+	if f.SubjectEqualsTo != nil {
+		scopes = append(scopes, f.SubjectEqualsTo.Scope(constants.Subject))
+	}
+	if f.GradeEqualsTo != nil {
+		scopes = append(scopes, f.GradeEqualsTo.Scope(constants.Grade))
+	}
+	if f.TopicEqualsTo != nil {
+		scopes = append(scopes, f.TopicEqualsTo.Scope(constants.Topic))
+	}
+	if f.DifficultyEqualsTo != nil {
+		scopes = append(scopes, f.DifficultyEqualsTo.Scope(constants.Difficulty))
+	}
+
+	return scopes
+}
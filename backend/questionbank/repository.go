@@ -0,0 +1,292 @@
+package questionbank
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/mcqoption"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/question"
+	redis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateBankQuestion(bankQuestion *BankQuestion, bankMcqOptions []*BankMcqOption) (*BankQuestion, error)
+	GetBankQuestionByID(id uint) (*BankQuestion, error)
+	GetBankQuestions(pagination *lib.QueryPagination, filter *GetBankQuestionsFilter) ([]*BankQuestion, error)
+	GetBankQuestionsIDOnly(filter *GetBankQuestionsFilter) ([]*BankQuestion, error)
+	GetBankMcqOptionsByBankQuestionID(bankQuestionID uint) ([]*BankMcqOption, error)
+	UpdateBankQuestion(bankQuestion *BankQuestion, bankMcqOptions []*BankMcqOption) error
+	DeleteBankQuestionByID(id uint) error
+	SnapshotBankQuestionsToExam(examID uint, bankQuestionIDs []uint) ([]*question.Question, error)
+}
+
+type repository struct {
+	cfg                *config.Config
+	db                 *gorm.DB
+	cache              *redis.Client
+	questionRepository question.Repository
+}
+
+func NewRepository(
+	cfg *config.Config,
+	db *gorm.DB,
+	cache *redis.Client,
+	questionRepository question.Repository,
+) Repository {
+	return &repository{
+		cfg:                cfg,
+		db:                 db,
+		cache:              cache,
+		questionRepository: questionRepository,
+	}
+}
+
+func (r *repository) CreateBankQuestion(bankQuestion *BankQuestion, bankMcqOptions []*BankMcqOption) (*BankQuestion, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(bankQuestion).Error; err != nil {
+			return err
+		}
+		if len(bankMcqOptions) == 0 {
+			return nil
+		}
+		for i := range bankMcqOptions {
+			bankMcqOptions[i].BankQuestionID = bankQuestion.ID
+		}
+		return tx.CreateInBatches(bankMcqOptions, constants.InsertionBatchSize).Error
+	})
+	return bankQuestion, err
+}
+
+func (r *repository) GetBankQuestionByID(id uint) (*BankQuestion, error) {
+	var bankQuestion BankQuestion
+
+	cacheKey := r.GetBankQuestionByIDCacheKey(id)
+	val, err := r.cache.Get(context.Background(), cacheKey).Result()
+	if err == nil {
+		json.Unmarshal([]byte(val), &bankQuestion)
+		return &bankQuestion, nil
+	}
+
+	err = r.db.Where("id = ?", id).First(&bankQuestion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, lib.ErrBankQuestionNotFound
+		}
+		return nil, err
+	}
+
+	res, _ := json.Marshal(bankQuestion)
+	r.cache.Set(context.Background(), cacheKey, res, r.cfg.CacheTTL)
+	return &bankQuestion, nil
+}
+
+func (r *repository) GetBankQuestions(pagination *lib.QueryPagination, filter *GetBankQuestionsFilter) ([]*BankQuestion, error) {
+	var res []*BankQuestion
+	err := r.db.Scopes(append(filter.Scope(), pagination.Scope())...).Find(&res).Error
+	return res, err
+}
+
+func (r *repository) GetBankQuestionsIDOnly(filter *GetBankQuestionsFilter) ([]*BankQuestion, error) {
+	var res []*BankQuestion
+	err := r.db.Select("id").Scopes(filter.Scope()...).Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (r *repository) GetBankMcqOptionsByBankQuestionID(bankQuestionID uint) ([]*BankMcqOption, error) {
+	var bankMcqOptions []*BankMcqOption
+
+	cacheKey := r.GetBankMcqOptionsByBankQuestionIDCacheKey(bankQuestionID)
+	val, err := r.cache.Get(context.Background(), cacheKey).Result()
+	if err == nil {
+		json.Unmarshal([]byte(val), &bankMcqOptions)
+		return bankMcqOptions, nil
+	}
+
+	err = r.db.Where("bank_question_id = ?", bankQuestionID).Order("id ASC").Find(&bankMcqOptions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res, _ := json.Marshal(bankMcqOptions)
+	r.cache.Set(context.Background(), cacheKey, res, r.cfg.CacheTTL)
+	return bankMcqOptions, nil
+}
+
+func (r *repository) UpdateBankQuestion(bankQuestion *BankQuestion, bankMcqOptions []*BankMcqOption) error {
+	currentData, err := r.GetBankQuestionByID(bankQuestion.ID)
+	if err != nil {
+		return err
+	}
+
+	// exams only hold snapshots of bank questions, so editing the bank never changes an exam that already used it
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&BankQuestion{}).
+			Where("id = ?", currentData.ID).
+			Updates(map[string]interface{}{
+				"subject":    bankQuestion.Subject,
+				"grade":      bankQuestion.Grade,
+				"topic":      bankQuestion.Topic,
+				"difficulty": bankQuestion.Difficulty,
+				"data":       bankQuestion.Data,
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("bank_question_id = ?", currentData.ID).Delete(&BankMcqOption{}).Error; err != nil {
+			return err
+		}
+		if len(bankMcqOptions) == 0 {
+			return nil
+		}
+		for i := range bankMcqOptions {
+			bankMcqOptions[i].BankQuestionID = currentData.ID
+		}
+		return tx.CreateInBatches(bankMcqOptions, constants.InsertionBatchSize).Error
+	})
+
+	if err == nil {
+		r.cache.Del(context.Background(), r.GetBankQuestionByIDCacheKey(currentData.ID))
+		r.cache.Del(context.Background(), r.GetBankMcqOptionsByBankQuestionIDCacheKey(currentData.ID))
+	}
+	return err
+}
+
+func (r *repository) DeleteBankQuestionByID(id uint) error {
+	currentData, err := r.GetBankQuestionByID(id)
+	if err != nil {
+		return err
+	}
+
+	res := r.db.Model(&BankQuestion{}).Where("id = ?", id).Delete(&BankQuestion{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Printf("[questionbank][repository][DeleteBankQuestionByID] error: %s", res.Error)
+		return lib.ErrBankQuestionNotFound
+	}
+
+	r.cache.Del(context.Background(), r.GetBankQuestionByIDCacheKey(currentData.ID))
+	r.cache.Del(context.Background(), r.GetBankMcqOptionsByBankQuestionIDCacheKey(currentData.ID))
+
+	return nil
+}
+
+func (r *repository) SnapshotBankQuestionsToExam(examID uint, bankQuestionIDs []uint) ([]*question.Question, error) {
+	snapshots := map[uint]*question.Question{}
+
+	// copied in id order, so concurrent snapshots of the same exam wait on each other instead of deadlocking
+	sortedBankQuestionIDs := append([]uint{}, bankQuestionIDs...)
+	sort.Slice(sortedBankQuestionIDs, func(i, j int) bool {
+		return sortedBankQuestionIDs[i] < sortedBankQuestionIDs[j]
+	})
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, bankQuestionID := range sortedBankQuestionIDs {
+			if _, ok := snapshots[bankQuestionID]; ok {
+				continue
+			}
+			snapshot, err := r.snapshotBankQuestionToExam(tx, examID, bankQuestionID)
+			if err != nil {
+				return err
+			}
+			snapshots[bankQuestionID] = snapshot
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Del(context.Background(), r.questionRepository.GetQuestionsIDByExamIDCacheKey(examID))
+
+	res := []*question.Question{}
+	for _, bankQuestionID := range bankQuestionIDs {
+		res = append(res, snapshots[bankQuestionID])
+	}
+	return res, nil
+}
+
+// snapshotBankQuestionToExam copies a bank question into the exam, or returns the copy made before.
+// The unique index on (exam_id, bank_question_id) keeps concurrent first snapshots from copying it twice, the later one reads the copy of the earlier one.
+func (r *repository) snapshotBankQuestionToExam(tx *gorm.DB, examID uint, bankQuestionID uint) (*question.Question, error) {
+	var existingQuestion question.Question
+	err := tx.Where("exam_id = ? AND bank_question_id = ?", examID, bankQuestionID).First(&existingQuestion).Error
+	if err == nil {
+		return &existingQuestion, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var bankQuestion BankQuestion
+	err = tx.Where("id = ?", bankQuestionID).First(&bankQuestion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, lib.ErrBankQuestionNotFound
+		}
+		return nil, err
+	}
+
+	var bankMcqOptions []*BankMcqOption
+	err = tx.Where("bank_question_id = ?", bankQuestion.ID).Order("id ASC").Find(&bankMcqOptions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &question.Question{
+		ExamID:         examID,
+		Data:           bankQuestion.Data,
+		BankQuestionID: &bankQuestion.ID,
+	}
+	err = tx.Create(snapshot).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// the insert waited for the concurrent copy to commit, which a locking read sees past this transaction's snapshot
+		err = tx.Clauses(clause.Locking{Strength: "SHARE"}).
+			Where("exam_id = ? AND bank_question_id = ?", examID, bankQuestionID).
+			First(&existingQuestion).Error
+		if err != nil {
+			return nil, err
+		}
+		return &existingQuestion, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Model(snapshot).Where("id = ?", snapshot.ID).Update(constants.OrderNumber, snapshot.ID).Error; err != nil {
+		return nil, err
+	}
+	snapshot.OrderNumber = snapshot.ID
+
+	if len(bankMcqOptions) > 0 {
+		mcqOptions := []*mcqoption.McqOption{}
+		for _, bankMcqOption := range bankMcqOptions {
+			mcqOptions = append(mcqOptions, &mcqoption.McqOption{
+				QuestionID:  snapshot.ID,
+				Description: bankMcqOption.Description,
+				Point:       bankMcqOption.Point,
+			})
+		}
+		if err := tx.CreateInBatches(mcqOptions, constants.InsertionBatchSize).Error; err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+func (r *repository) GetBankQuestionByIDCacheKey(id uint) string {
+	return fmt.Sprintf("bank_question:id:%d", id)
+}
+
+func (r *repository) GetBankMcqOptionsByBankQuestionIDCacheKey(bankQuestionID uint) string {
+	return fmt.Sprintf("bank_mcq_option_list:bankQuestionID:%d", bankQuestionID)
+}
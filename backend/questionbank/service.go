@@ -0,0 +1,116 @@
+package questionbank
+
+import (
+	"errors"
+	"log"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/question"
+)
+
+type Service interface {
+	CreateBankQuestion(bankQuestion *BankQuestion, bankMcqOptions []*BankMcqOption) (*BankQuestion, error)
+	GetBankQuestionByID(id uint) (*BankQuestion, error)
+	GetBankQuestions(pagination *lib.QueryPagination, filter *GetBankQuestionsFilter) ([]*BankQuestion, error)
+	GetBankQuestionsIDOnly(filter *GetBankQuestionsFilter) ([]*BankQuestion, error)
+	GetBankMcqOptionsByBankQuestionID(bankQuestionID uint) ([]*BankMcqOption, error)
+	UpdateBankQuestion(bankQuestion *BankQuestion, bankMcqOptions []*BankMcqOption) error
+	DeleteBankQuestionByID(id uint) error
+	SnapshotBankQuestionsToExam(examID uint, bankQuestionIDs []uint) ([]*question.Question, error)
+}
+
+type service struct {
+	questionBankRepository Repository
+}
+
+func NewService(
+	questionBankRepository Repository,
+) Service {
+	return &service{
+		questionBankRepository: questionBankRepository,
+	}
+}
+
+func (s *service) CreateBankQuestion(bankQuestion *BankQuestion, bankMcqOptions []*BankMcqOption) (*BankQuestion, error) {
+	res, err := s.questionBankRepository.CreateBankQuestion(bankQuestion, bankMcqOptions)
+	if err != nil {
+		log.Println("[questionbank][service][CreateBankQuestion] failed to create bank question:", err.Error())
+		return nil, lib.ErrFailedToCreateBankQuestion
+	}
+	return res, nil
+}
+
+func (s *service) GetBankQuestionByID(id uint) (*BankQuestion, error) {
+	res, err := s.questionBankRepository.GetBankQuestionByID(id)
+	if err != nil {
+		log.Println("[questionbank][service][GetBankQuestionByID] failed to get bank question by id:", err.Error())
+		if errors.Is(err, lib.ErrBankQuestionNotFound) {
+			return nil, err
+		}
+		return nil, lib.ErrFailedToGetBankQuestion
+	}
+	return res, nil
+}
+
+func (s *service) GetBankQuestions(pagination *lib.QueryPagination, filter *GetBankQuestionsFilter) ([]*BankQuestion, error) {
+	res, err := s.questionBankRepository.GetBankQuestions(pagination, filter)
+	if err != nil {
+		log.Println("[questionbank][service][GetBankQuestions] failed to get bank questions:", err.Error())
+		return nil, lib.ErrFailedToGetBankQuestions
+	}
+	return res, nil
+}
+
+func (s *service) GetBankQuestionsIDOnly(filter *GetBankQuestionsFilter) ([]*BankQuestion, error) {
+	res, err := s.questionBankRepository.GetBankQuestionsIDOnly(filter)
+	if err != nil {
+		log.Println("[questionbank][service][GetBankQuestionsIDOnly] failed to get bank questions:", err.Error())
+		return nil, lib.ErrFailedToGetBankQuestions
+	}
+	return res, nil
+}
+
+func (s *service) GetBankMcqOptionsByBankQuestionID(bankQuestionID uint) ([]*BankMcqOption, error) {
+	res, err := s.questionBankRepository.GetBankMcqOptionsByBankQuestionID(bankQuestionID)
+	if err != nil {
+		log.Println("[questionbank][service][GetBankMcqOptionsByBankQuestionID] failed to get bank mcq options:", err.Error())
+		return nil, lib.ErrFailedToGetBankMcqOptions
+	}
+	return res, nil
+}
+
+func (s *service) UpdateBankQuestion(bankQuestion *BankQuestion, bankMcqOptions []*BankMcqOption) error {
+	err := s.questionBankRepository.UpdateBankQuestion(bankQuestion, bankMcqOptions)
+	if err != nil {
+		log.Println("[questionbank][service][UpdateBankQuestion] failed to update bank question:", err.Error())
+		if errors.Is(err, lib.ErrBankQuestionNotFound) {
+			return err
+		}
+		return lib.ErrFailedToUpdateBankQuestion
+	}
+	return nil
+}
+
+func (s *service) DeleteBankQuestionByID(id uint) error {
+	err := s.questionBankRepository.DeleteBankQuestionByID(id)
+	if err != nil {
+		log.Println("[questionbank][service][DeleteBankQuestionByID] failed to delete bank question:", err.Error())
+		if errors.Is(err, lib.ErrBankQuestionNotFound) {
+			return err
+		}
+		return lib.ErrFailedToDeleteBankQuestion
+	}
+	return nil
+}
+
+func (s *service) SnapshotBankQuestionsToExam(examID uint, bankQuestionIDs []uint) ([]*question.Question, error) {
+	res, err := s.questionBankRepository.SnapshotBankQuestionsToExam(examID, bankQuestionIDs)
+	if err != nil {
+		log.Println("[questionbank][service][SnapshotBankQuestionsToExam] failed to snapshot bank questions:", err.Error())
+		if errors.Is(err, lib.ErrBankQuestionNotFound) {
+			return nil, err
+		}
+		return nil, lib.ErrFailedToSnapshotBankQuestions
+	}
+	return res, nil
+}
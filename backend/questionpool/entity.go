@@ -0,0 +1,22 @@
+package questionpool
+
+import (
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+type ExamQuestionPool struct {
+	lib.BaseModel
+	ExamID     uint
+	Subject    string
+	Grade      string
+	Topic      string
+	Difficulty string
+	DrawCount  uint
+}
+
+type ParticipantQuestion struct {
+	lib.BaseModel
+	ParticipantID uint
+	QuestionID    uint
+	OrderNumber   uint
+}
@@ -0,0 +1,174 @@
+package questionpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	redis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateExamQuestionPool(examQuestionPool *ExamQuestionPool) (*ExamQuestionPool, error)
+	GetExamQuestionPoolByID(id uint) (*ExamQuestionPool, error)
+	GetExamQuestionPoolsByExamID(examID uint) ([]*ExamQuestionPool, error)
+	DeleteExamQuestionPoolByID(id uint) error
+
+	CreateParticipantQuestions(participantID uint, participantQuestions []*ParticipantQuestion) ([]*ParticipantQuestion, error)
+	GetParticipantQuestionsByParticipantID(participantID uint) ([]*ParticipantQuestion, error)
+	GetParticipantQuestionsByExamID(examID uint) ([]*ParticipantQuestion, error)
+}
+
+type repository struct {
+	cfg   *config.Config
+	db    *gorm.DB
+	cache *redis.Client
+}
+
+func NewRepository(
+	cfg *config.Config,
+	db *gorm.DB,
+	cache *redis.Client,
+) Repository {
+	return &repository{
+		cfg:   cfg,
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (r *repository) CreateExamQuestionPool(examQuestionPool *ExamQuestionPool) (*ExamQuestionPool, error) {
+	err := r.db.Create(examQuestionPool).Error
+	if err == nil {
+		r.cache.Del(context.Background(), r.GetExamQuestionPoolsByExamIDCacheKey(examQuestionPool.ExamID))
+	}
+	return examQuestionPool, err
+}
+
+func (r *repository) GetExamQuestionPoolByID(id uint) (*ExamQuestionPool, error) {
+	var examQuestionPool ExamQuestionPool
+	err := r.db.Where("id = ?", id).First(&examQuestionPool).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, lib.ErrExamQuestionPoolNotFound
+		}
+		return nil, err
+	}
+	return &examQuestionPool, nil
+}
+
+func (r *repository) GetExamQuestionPoolsByExamID(examID uint) ([]*ExamQuestionPool, error) {
+	var examQuestionPools []*ExamQuestionPool
+
+	cacheKey := r.GetExamQuestionPoolsByExamIDCacheKey(examID)
+	val, err := r.cache.Get(context.Background(), cacheKey).Result()
+	if err == nil {
+		json.Unmarshal([]byte(val), &examQuestionPools)
+		return examQuestionPools, nil
+	}
+
+	err = r.db.Where("exam_id = ?", examID).Order("id ASC").Find(&examQuestionPools).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res, _ := json.Marshal(examQuestionPools)
+	r.cache.Set(context.Background(), cacheKey, res, r.cfg.CacheTTL)
+	return examQuestionPools, nil
+}
+
+func (r *repository) DeleteExamQuestionPoolByID(id uint) error {
+	currentData, err := r.GetExamQuestionPoolByID(id)
+	if err != nil {
+		return err
+	}
+
+	res := r.db.Model(&ExamQuestionPool{}).Where("id = ?", id).Delete(&ExamQuestionPool{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.Printf("[questionpool][repository][DeleteExamQuestionPoolByID] error: %s", res.Error)
+		return lib.ErrExamQuestionPoolNotFound
+	}
+
+	r.cache.Del(context.Background(), r.GetExamQuestionPoolsByExamIDCacheKey(currentData.ExamID))
+	return nil
+}
+
+// CreateParticipantQuestions stores the draw of the participant unless one is stored already, and returns the stored one.
+// The participant row is locked while checking, so concurrent draws of the same participant store only one of them.
+func (r *repository) CreateParticipantQuestions(participantID uint, participantQuestions []*ParticipantQuestion) ([]*ParticipantQuestion, error) {
+	res := participantQuestions
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var lockedParticipantIDs []uint
+		err := tx.Table("participants").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", participantID).
+			Pluck("id", &lockedParticipantIDs).Error
+		if err != nil {
+			return err
+		}
+
+		var existing []*ParticipantQuestion
+		err = tx.Where("participant_id = ? AND not_archived", participantID).Order("order_number ASC").Find(&existing).Error
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			res = existing
+			return nil
+		}
+		return tx.CreateInBatches(participantQuestions, constants.InsertionBatchSize).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Del(context.Background(), r.GetParticipantQuestionsByParticipantIDCacheKey(participantID))
+	return res, nil
+}
+
+func (r *repository) GetParticipantQuestionsByParticipantID(participantID uint) ([]*ParticipantQuestion, error) {
+	var participantQuestions []*ParticipantQuestion
+
+	cacheKey := r.GetParticipantQuestionsByParticipantIDCacheKey(participantID)
+	val, err := r.cache.Get(context.Background(), cacheKey).Result()
+	if err == nil {
+		json.Unmarshal([]byte(val), &participantQuestions)
+		return participantQuestions, nil
+	}
+
+	err = r.db.Where("participant_id = ? AND not_archived", participantID).Order("order_number ASC").Find(&participantQuestions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res, _ := json.Marshal(participantQuestions)
+	r.cache.Set(context.Background(), cacheKey, res, r.cfg.CacheTTL)
+	return participantQuestions, nil
+}
+
+func (r *repository) GetParticipantQuestionsByExamID(examID uint) ([]*ParticipantQuestion, error) {
+	var res []*ParticipantQuestion
+	err := r.db.
+		Joins("JOIN participants p ON p.id = participant_questions.participant_id").
+		Where("p.exam_id = ? AND p.deleted_at IS NULL AND participant_questions.not_archived", examID).
+		Order("participant_questions.participant_id ASC, participant_questions.order_number ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (r *repository) GetExamQuestionPoolsByExamIDCacheKey(examID uint) string {
+	return fmt.Sprintf("exam_question_pool_list:examID:%d", examID)
+}
+
+func (r *repository) GetParticipantQuestionsByParticipantIDCacheKey(participantID uint) string {
+	return fmt.Sprintf("participant_question_list:participantID:%d", participantID)
+}
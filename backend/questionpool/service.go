@@ -0,0 +1,219 @@
+package questionpool
+
+import (
+	"errors"
+	"log"
+	"math/rand/v2"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/question"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/questionbank"
+)
+
+type Service interface {
+	CreateExamQuestionPool(examQuestionPool *ExamQuestionPool) (*ExamQuestionPool, error)
+	GetExamQuestionPoolsByExamID(examID uint) ([]*ExamQuestionPool, error)
+	DeleteExamQuestionPoolByID(id uint) error
+
+	DrawParticipantQuestions(participantID uint, examID uint) ([]*ParticipantQuestion, error)
+	GetParticipantQuestionsByParticipantID(participantID uint) ([]*ParticipantQuestion, error)
+	GetParticipantQuestionsByExamID(examID uint) ([]*ParticipantQuestion, error)
+	IsQuestionServedToParticipant(participantID uint, questionID uint) (bool, error)
+	GetServedQuestionIDs(participantID uint, examID uint) ([]uint, error)
+}
+
+type service struct {
+	questionPoolRepository Repository
+	questionBankService    questionbank.Service
+	questionService        question.Service
+}
+
+func NewService(
+	questionPoolRepository Repository,
+	questionBankService questionbank.Service,
+	questionService question.Service,
+) Service {
+	return &service{
+		questionPoolRepository: questionPoolRepository,
+		questionBankService:    questionBankService,
+		questionService:        questionService,
+	}
+}
+
+func (s *service) CreateExamQuestionPool(examQuestionPool *ExamQuestionPool) (*ExamQuestionPool, error) {
+	res, err := s.questionPoolRepository.CreateExamQuestionPool(examQuestionPool)
+	if err != nil {
+		log.Println("[questionpool][service][CreateExamQuestionPool] failed to create exam question pool:", err.Error())
+		return nil, lib.ErrFailedToCreateExamQuestionPool
+	}
+	return res, nil
+}
+
+func (s *service) GetExamQuestionPoolsByExamID(examID uint) ([]*ExamQuestionPool, error) {
+	res, err := s.questionPoolRepository.GetExamQuestionPoolsByExamID(examID)
+	if err != nil {
+		log.Println("[questionpool][service][GetExamQuestionPoolsByExamID] failed to get exam question pools:", err.Error())
+		return nil, lib.ErrFailedToGetExamQuestionPools
+	}
+	return res, nil
+}
+
+func (s *service) DeleteExamQuestionPoolByID(id uint) error {
+	err := s.questionPoolRepository.DeleteExamQuestionPoolByID(id)
+	if err != nil {
+		log.Println("[questionpool][service][DeleteExamQuestionPoolByID] failed to delete exam question pool:", err.Error())
+		if errors.Is(err, lib.ErrExamQuestionPoolNotFound) {
+			return err
+		}
+		return lib.ErrFailedToDeleteExamQuestionPool
+	}
+	return nil
+}
+
+// DrawParticipantQuestions picks the participant's question set from the exam pools once, and returns the stored draw afterwards.
+// It returns an empty list if the exam has no pools, in which case every question of the exam is served.
+func (s *service) DrawParticipantQuestions(participantID uint, examID uint) ([]*ParticipantQuestion, error) {
+	existing, err := s.GetParticipantQuestionsByParticipantID(participantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return existing, nil
+	}
+
+	pools, err := s.GetExamQuestionPoolsByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pools) == 0 {
+		return existing, nil
+	}
+
+	drawnBankQuestionIDs := []uint{}
+	isDrawn := map[uint]bool{}
+	for _, pool := range pools {
+		candidates, err := s.questionBankService.GetBankQuestionsIDOnly(s.MapExamQuestionPoolToGetBankQuestionsFilter(pool))
+		if err != nil {
+			return nil, lib.ErrFailedToDrawParticipantQuestions
+		}
+
+		rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+
+		drawCount := uint(0)
+		for _, candidate := range candidates {
+			if drawCount == pool.DrawCount {
+				break
+			}
+			if isDrawn[candidate.ID] {
+				continue
+			}
+			isDrawn[candidate.ID] = true
+			drawnBankQuestionIDs = append(drawnBankQuestionIDs, candidate.ID)
+			drawCount++
+		}
+		if drawCount < pool.DrawCount {
+			log.Printf("[questionpool][service][DrawParticipantQuestions] pool %d only has %d of %d bank questions", pool.ID, drawCount, pool.DrawCount)
+			return nil, lib.ErrInsufficientBankQuestionsInPool
+		}
+	}
+
+	// the same bank question always maps to the same exam question, so item statistics stay comparable across forms
+	questions, err := s.questionBankService.SnapshotBankQuestionsToExam(examID, drawnBankQuestionIDs)
+	if err != nil {
+		return nil, lib.ErrFailedToDrawParticipantQuestions
+	}
+
+	participantQuestions := []*ParticipantQuestion{}
+	for i, q := range questions {
+		participantQuestions = append(participantQuestions, &ParticipantQuestion{
+			ParticipantID: participantID,
+			QuestionID:    q.ID,
+			OrderNumber:   uint(i + 1),
+		})
+	}
+
+	// a concurrent request may have stored its draw first, which is then served instead of this one
+	res, err := s.questionPoolRepository.CreateParticipantQuestions(participantID, participantQuestions)
+	if err != nil {
+		log.Println("[questionpool][service][DrawParticipantQuestions] failed to save participant questions:", err.Error())
+		return nil, lib.ErrFailedToDrawParticipantQuestions
+	}
+	return res, nil
+}
+
+func (s *service) GetParticipantQuestionsByParticipantID(participantID uint) ([]*ParticipantQuestion, error) {
+	res, err := s.questionPoolRepository.GetParticipantQuestionsByParticipantID(participantID)
+	if err != nil {
+		log.Println("[questionpool][service][GetParticipantQuestionsByParticipantID] failed to get participant questions:", err.Error())
+		return nil, lib.ErrFailedToGetParticipantQuestions
+	}
+	return res, nil
+}
+
+func (s *service) GetParticipantQuestionsByExamID(examID uint) ([]*ParticipantQuestion, error) {
+	res, err := s.questionPoolRepository.GetParticipantQuestionsByExamID(examID)
+	if err != nil {
+		log.Println("[questionpool][service][GetParticipantQuestionsByExamID] failed to get participant questions:", err.Error())
+		return nil, lib.ErrFailedToGetParticipantQuestions
+	}
+	return res, nil
+}
+
+func (s *service) IsQuestionServedToParticipant(participantID uint, questionID uint) (bool, error) {
+	participantQuestions, err := s.GetParticipantQuestionsByParticipantID(participantID)
+	if err != nil {
+		return false, err
+	}
+	if len(participantQuestions) == 0 {
+		return true, nil
+	}
+	for _, participantQuestion := range participantQuestions {
+		if participantQuestion.QuestionID == questionID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetServedQuestionIDs returns the questions drawn for the participant, or every question of the exam when it has no question pools.
+func (s *service) GetServedQuestionIDs(participantID uint, examID uint) ([]uint, error) {
+	res := []uint{}
+	participantQuestions, err := s.GetParticipantQuestionsByParticipantID(participantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(participantQuestions) > 0 {
+		for _, participantQuestion := range participantQuestions {
+			res = append(res, participantQuestion.QuestionID)
+		}
+		return res, nil
+	}
+
+	questions, err := s.questionService.GetQuestionsIDByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range questions {
+		res = append(res, q.ID)
+	}
+	return res, nil
+}
+
+func (s *service) MapExamQuestionPoolToGetBankQuestionsFilter(pool *ExamQuestionPool) *questionbank.GetBankQuestionsFilter {
+	filter := &questionbank.GetBankQuestionsFilter{}
+	if pool.Subject != "" {
+		filter.SubjectEqualsTo = &lib.QueryFiltersEqualToString{Value: pool.Subject}
+	}
+	if pool.Grade != "" {
+		filter.GradeEqualsTo = &lib.QueryFiltersEqualToString{Value: pool.Grade}
+	}
+	if pool.Topic != "" {
+		filter.TopicEqualsTo = &lib.QueryFiltersEqualToString{Value: pool.Topic}
+	}
+	if pool.Difficulty != "" {
+		filter.DifficultyEqualsTo = &lib.QueryFiltersEqualToString{Value: pool.Difficulty}
+	}
+	return filter
+}
@@ -0,0 +1,50 @@
+package receipt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
+)
+
+// SubmissionReceipt is the proof of the answers a participant submitted, signed so it cannot be forged by the holder.
+type SubmissionReceipt struct {
+	lib.BaseModel
+	ParticipantID uint
+	ExamID        uint
+	AnswerCount   int
+	AnswerHash    string
+	SubmittedAt   time.Time
+	Signature     string
+}
+
+type ReceiptVerification struct {
+	IsSignatureValid  bool
+	IsAnswerUnchanged bool // the answers in the database still hash to the receipt's answer hash
+}
+
+// GetPayload is the signed content of the receipt.
+func (r *SubmissionReceipt) GetPayload() string {
+	return fmt.Sprintf("%d|%d|%d|%s|%d", r.ParticipantID, r.ExamID, r.AnswerCount, r.AnswerHash, r.SubmittedAt.Unix())
+}
+
+func (r *SubmissionReceipt) Sign(key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(r.GetPayload()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// HashAnswers hashes the answers as "question id:option id" lines, the answers have to be ordered by question id.
+func HashAnswers(answers []*submission.Submission) string {
+	var sb strings.Builder
+	for _, answer := range answers {
+		sb.WriteString(fmt.Sprintf("%d:%d\n", answer.QuestionID, answer.McqOptionID))
+	}
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])
+}
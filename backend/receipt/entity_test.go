@@ -0,0 +1,52 @@
+package receipt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
+)
+
+func TestHashAnswers(t *testing.T) {
+	answers := []*submission.Submission{
+		{QuestionID: 1, McqOptionID: 3},
+		{QuestionID: 2, McqOptionID: 5},
+	}
+	hash := HashAnswers(answers)
+	if hash != HashAnswers([]*submission.Submission{{QuestionID: 1, McqOptionID: 3}, {QuestionID: 2, McqOptionID: 5}}) {
+		t.Fatal("HashAnswers() differs for the same answers")
+	}
+	if hash == HashAnswers([]*submission.Submission{{QuestionID: 1, McqOptionID: 3}, {QuestionID: 2, McqOptionID: 6}}) {
+		t.Fatal("HashAnswers() is the same after an answer changed")
+	}
+	if hash == HashAnswers(answers[:1]) {
+		t.Fatal("HashAnswers() is the same after an answer was removed")
+	}
+	if HashAnswers(nil) == "" {
+		t.Fatal("HashAnswers() is empty without answers")
+	}
+}
+
+func TestSubmissionReceiptSign(t *testing.T) {
+	receipt := &SubmissionReceipt{
+		ParticipantID: 7,
+		ExamID:        3,
+		AnswerCount:   2,
+		AnswerHash:    "hash",
+		SubmittedAt:   time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+	key := []byte("key")
+	signature := receipt.Sign(key)
+	if signature != receipt.Sign(key) {
+		t.Fatal("Sign() differs for the same receipt")
+	}
+	if signature == receipt.Sign([]byte("other key")) {
+		t.Fatal("Sign() is the same with another key")
+	}
+
+	tampered := *receipt
+	tampered.AnswerCount = 3
+	if signature == tampered.Sign(key) {
+		t.Fatal("Sign() is the same after the payload changed")
+	}
+}
@@ -0,0 +1,87 @@
+package receipt
+
+import (
+	"errors"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
+	redis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateSubmissionReceipt(receipt *SubmissionReceipt) (*SubmissionReceipt, error)
+	GetSubmissionReceiptByParticipantID(participantID uint) (*SubmissionReceipt, error)
+	GetEndedParticipantsWithoutReceipt(limit int) ([]*participant.Participant, error)
+}
+
+type repository struct {
+	cfg   *config.Config
+	db    *gorm.DB
+	cache *redis.Client
+}
+
+func NewRepository(
+	cfg *config.Config,
+	db *gorm.DB,
+	cache *redis.Client,
+) Repository {
+	return &repository{
+		cfg:   cfg,
+		db:    db,
+		cache: cache,
+	}
+}
+
+// CreateSubmissionReceipt hashes the stored answers of the participant into the receipt, signs it and stores it in one transaction.
+// The participant row is locked exclusively meanwhile, which the answer upserts wait for, so no answer lands between the hash and the receipt.
+func (r *repository) CreateSubmissionReceipt(receipt *SubmissionReceipt) (*SubmissionReceipt, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var participantIDs []uint
+		err := tx.Table("participants").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", receipt.ParticipantID).
+			Pluck("id", &participantIDs).Error
+		if err != nil {
+			return err
+		}
+
+		var answers []*submission.Submission
+		err = tx.Where("participant_id = ? AND not_archived", receipt.ParticipantID).Order("question_id ASC").Find(&answers).Error
+		if err != nil {
+			return err
+		}
+		receipt.AnswerCount = len(answers)
+		receipt.AnswerHash = HashAnswers(answers)
+		receipt.Signature = receipt.Sign(r.cfg.AuthConfig.ReceiptSignatureKey)
+		return tx.Create(receipt).Error
+	})
+	return receipt, err
+}
+
+func (r *repository) GetSubmissionReceiptByParticipantID(participantID uint) (*SubmissionReceipt, error) {
+	var receipt SubmissionReceipt
+	err := r.db.Where("participant_id = ? AND not_archived", participantID).First(&receipt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, lib.ErrReceiptNotFound
+		}
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+// GetEndedParticipantsWithoutReceipt returns the submitted participants whose receipt could not be issued yet, the earliest submitted first.
+func (r *repository) GetEndedParticipantsWithoutReceipt(limit int) ([]*participant.Participant, error) {
+	var res []*participant.Participant
+	err := r.db.
+		Where("ended_at IS NOT NULL").
+		Where("NOT EXISTS (SELECT 1 FROM submission_receipts sr WHERE sr.participant_id = participants.id AND sr.not_archived)").
+		Order("ended_at ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
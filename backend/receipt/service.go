@@ -0,0 +1,120 @@
+package receipt
+
+import (
+	"crypto/hmac"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
+)
+
+type Service interface {
+	IssueReceipt(participant *participant.Participant, questionIDs []uint) (*SubmissionReceipt, error)
+	GetReceiptByParticipantID(participantID uint) (*SubmissionReceipt, error)
+	VerifyReceiptSignature(receipt *SubmissionReceipt) bool
+	VerifyReceipt(receipt *SubmissionReceipt) (*ReceiptVerification, error)
+	GetEndedParticipantsWithoutReceipt() ([]*participant.Participant, error)
+}
+
+type service struct {
+	cfg               *config.Config
+	receiptRepository Repository
+	submissionService submission.Service
+}
+
+func NewService(
+	cfg *config.Config,
+	receiptRepository Repository,
+	submissionService submission.Service,
+) Service {
+	return &service{
+		cfg:               cfg,
+		receiptRepository: receiptRepository,
+		submissionService: submissionService,
+	}
+}
+
+// IssueReceipt flushes the cached answers of the submitted participant into the database and signs their hash.
+// A participant only gets one receipt, so issuing again returns the first one, and no answer is written once it exists.
+func (s *service) IssueReceipt(participant *participant.Participant, questionIDs []uint) (*SubmissionReceipt, error) {
+	existing, err := s.receiptRepository.GetSubmissionReceiptByParticipantID(participant.ID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, lib.ErrReceiptNotFound) {
+		log.Println("[receipt][service][IssueReceipt] failed to get receipt:", err.Error())
+		return nil, lib.ErrFailedToIssueReceipt
+	}
+
+	err = s.submissionService.FlushAnswers(participant.ID, questionIDs)
+	if err != nil {
+		log.Println("[receipt][service][IssueReceipt] failed to flush answers:", err.Error())
+		return nil, lib.ErrFailedToIssueReceipt
+	}
+
+	submittedAt := time.Now()
+	if participant.EndedAt != nil {
+		submittedAt = *participant.EndedAt
+	}
+	receipt := &SubmissionReceipt{
+		ParticipantID: participant.ID,
+		ExamID:        participant.ExamID,
+		SubmittedAt:   submittedAt.Truncate(time.Second), // stored without fractional seconds
+	}
+
+	res, err := s.receiptRepository.CreateSubmissionReceipt(receipt)
+	if err != nil {
+		log.Println("[receipt][service][IssueReceipt] failed to create receipt:", err.Error())
+		return nil, lib.ErrFailedToIssueReceipt
+	}
+	return res, nil
+}
+
+func (s *service) GetReceiptByParticipantID(participantID uint) (*SubmissionReceipt, error) {
+	res, err := s.receiptRepository.GetSubmissionReceiptByParticipantID(participantID)
+	if err != nil {
+		log.Println("[receipt][service][GetReceiptByParticipantID] failed to get receipt:", err.Error())
+		if errors.Is(err, lib.ErrReceiptNotFound) {
+			return nil, err
+		}
+		return nil, lib.ErrFailedToGetReceipt
+	}
+	return res, nil
+}
+
+// VerifyReceiptSignature checks that the receipt was issued by this server and was not altered.
+func (s *service) VerifyReceiptSignature(receipt *SubmissionReceipt) bool {
+	return hmac.Equal([]byte(receipt.Signature), []byte(receipt.Sign(s.cfg.AuthConfig.ReceiptSignatureKey)))
+}
+
+// VerifyReceipt checks the signature of a receipt, and whether the stored answers still match it.
+func (s *service) VerifyReceipt(receipt *SubmissionReceipt) (*ReceiptVerification, error) {
+	res := &ReceiptVerification{
+		IsSignatureValid: s.VerifyReceiptSignature(receipt),
+	}
+	if !res.IsSignatureValid {
+		return res, nil
+	}
+
+	answers, err := s.submissionService.GetAnswersInDB(receipt.ParticipantID)
+	if err != nil {
+		log.Println("[receipt][service][VerifyReceipt] failed to get answers:", err.Error())
+		return nil, lib.ErrFailedToVerifyReceipt
+	}
+	res.IsAnswerUnchanged = len(answers) == receipt.AnswerCount && HashAnswers(answers) == receipt.AnswerHash
+	return res, nil
+}
+
+func (s *service) GetEndedParticipantsWithoutReceipt() ([]*participant.Participant, error) {
+	res, err := s.receiptRepository.GetEndedParticipantsWithoutReceipt(constants.ReceiptRetryBatchSize)
+	if err != nil {
+		log.Println("[receipt][service][GetEndedParticipantsWithoutReceipt] failed to get participants:", err.Error())
+		return nil, lib.ErrFailedToGetReceipt
+	}
+	return res, nil
+}
@@ -0,0 +1,29 @@
+package reconciliation
+
+import (
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+// SubmissionReconciliation is a run that flushed the answers cached in redis into the database, scoped to an exam unless ExamID is nil.
+type SubmissionReconciliation struct {
+	lib.BaseModel
+	ExamID       *uint
+	ScannedCount int
+	MissingCount int
+	StaleCount   int
+	FailedCount  int
+	FinishedAt   time.Time
+
+	Items []*ReconciliationItem `gorm:"-"`
+}
+
+// ReconciliationItem is an answer which was missing or stale in the database, with Status "failed" when it could not be written.
+type ReconciliationItem struct {
+	ParticipantID uint
+	QuestionID    uint
+	Status        string
+}
+
+const ReconcileStatusFailed = "failed"
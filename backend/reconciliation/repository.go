@@ -0,0 +1,41 @@
+package reconciliation
+
+import (
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	redis "github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CreateSubmissionReconciliation(reconciliation *SubmissionReconciliation) (*SubmissionReconciliation, error)
+	GetSubmissionReconciliationsByExamID(examID uint) ([]*SubmissionReconciliation, error)
+}
+
+type repository struct {
+	cfg   *config.Config
+	db    *gorm.DB
+	cache *redis.Client
+}
+
+func NewRepository(
+	cfg *config.Config,
+	db *gorm.DB,
+	cache *redis.Client,
+) Repository {
+	return &repository{
+		cfg:   cfg,
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (r *repository) CreateSubmissionReconciliation(reconciliation *SubmissionReconciliation) (*SubmissionReconciliation, error) {
+	err := r.db.Create(reconciliation).Error
+	return reconciliation, err
+}
+
+func (r *repository) GetSubmissionReconciliationsByExamID(examID uint) ([]*SubmissionReconciliation, error) {
+	var res []*SubmissionReconciliation
+	err := r.db.Where("exam_id = ?", examID).Order("id DESC").Find(&res).Error
+	return res, err
+}
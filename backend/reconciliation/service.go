@@ -0,0 +1,160 @@
+package reconciliation
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/question"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
+)
+
+type Service interface {
+	ReconcileAll() (*SubmissionReconciliation, error)
+	ReconcileExam(examID uint) (*SubmissionReconciliation, error)
+	IsExamReconciled(examID uint) (bool, error)
+	GetSubmissionReconciliationsByExamID(examID uint) ([]*SubmissionReconciliation, error)
+}
+
+type service struct {
+	reconciliationRepository Repository
+	submissionService        submission.Service
+	participantService       participant.Service
+	questionService          question.Service
+}
+
+func NewService(
+	reconciliationRepository Repository,
+	submissionService submission.Service,
+	participantService participant.Service,
+	questionService question.Service,
+) Service {
+	return &service{
+		reconciliationRepository: reconciliationRepository,
+		submissionService:        submissionService,
+		participantService:       participantService,
+		questionService:          questionService,
+	}
+}
+
+// ReconcileAll flushes every cached answer, regardless of the exam.
+func (s *service) ReconcileAll() (*SubmissionReconciliation, error) {
+	keys, err := s.submissionService.GetCacheObjectKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	res := s.reconcileKeys(keys, false)
+	return s.saveSubmissionReconciliation(res)
+}
+
+func (s *service) ReconcileExam(examID uint) (*SubmissionReconciliation, error) {
+	statuses, err := s.getCacheObjectStatusesByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for key := range statuses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	res := s.reconcileKeys(keys, false)
+	res.ExamID = &examID
+	return s.saveSubmissionReconciliation(res)
+}
+
+// IsExamReconciled checks, without writing anything, that every cached answer of the exam is already in the database.
+func (s *service) IsExamReconciled(examID uint) (bool, error) {
+	statuses, err := s.getCacheObjectStatusesByExamID(examID)
+	if err != nil {
+		return false, err
+	}
+	for _, status := range statuses {
+		if status != submission.ReconcileStatusInSync {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *service) GetSubmissionReconciliationsByExamID(examID uint) ([]*SubmissionReconciliation, error) {
+	res, err := s.reconciliationRepository.GetSubmissionReconciliationsByExamID(examID)
+	if err != nil {
+		log.Println("[reconciliation][service][GetSubmissionReconciliationsByExamID] failed to get submission reconciliations:", err.Error())
+		return nil, lib.ErrFailedToGetSubmissionReconciliations
+	}
+	return res, nil
+}
+
+// getCacheObjectStatusesByExamID checks the answers which can exist for the exam, its participants by its questions, instead of scanning every cached answer.
+func (s *service) getCacheObjectStatusesByExamID(examID uint) (map[string]string, error) {
+	participants, err := s.participantService.GetParticipantsByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	participantIDs := []uint{}
+	for _, p := range participants {
+		participantIDs = append(participantIDs, p.ID)
+	}
+
+	questions, err := s.questionService.GetQuestionsIDByExamID(examID)
+	if err != nil {
+		return nil, err
+	}
+	questionIDs := []uint{}
+	for _, q := range questions {
+		questionIDs = append(questionIDs, q.ID)
+	}
+
+	return s.submissionService.GetCacheObjectStatuses(participantIDs, questionIDs)
+}
+
+func (s *service) reconcileKeys(keys []string, dryRun bool) *SubmissionReconciliation {
+	res := &SubmissionReconciliation{
+		Items: []*ReconciliationItem{},
+	}
+	for _, key := range keys {
+		res.ScannedCount++
+		participantID, questionID, _ := submission.ParseCacheObjectKey(key)
+
+		_, status, err := s.submissionService.ReconcileCacheObject(key, dryRun)
+		if err != nil {
+			res.FailedCount++
+			res.Items = append(res.Items, &ReconciliationItem{
+				ParticipantID: participantID,
+				QuestionID:    questionID,
+				Status:        ReconcileStatusFailed,
+			})
+			continue
+		}
+
+		switch status {
+		case submission.ReconcileStatusMissing:
+			res.MissingCount++
+		case submission.ReconcileStatusStale:
+			res.StaleCount++
+		default:
+			continue
+		}
+		res.Items = append(res.Items, &ReconciliationItem{
+			ParticipantID: participantID,
+			QuestionID:    questionID,
+			Status:        status,
+		})
+	}
+	res.FinishedAt = time.Now()
+	return res
+}
+
+func (s *service) saveSubmissionReconciliation(reconciliation *SubmissionReconciliation) (*SubmissionReconciliation, error) {
+	log.Printf("[reconciliation][service][saveSubmissionReconciliation] scanned %d, missing %d, stale %d, failed %d\n", reconciliation.ScannedCount, reconciliation.MissingCount, reconciliation.StaleCount, reconciliation.FailedCount)
+	res, err := s.reconciliationRepository.CreateSubmissionReconciliation(reconciliation)
+	if err != nil {
+		log.Println("[reconciliation][service][saveSubmissionReconciliation] failed to save submission reconciliation:", err.Error())
+		return nil, lib.ErrFailedToReconcileSubmissions
+	}
+	return res, nil
+}
@@ -2,27 +2,125 @@ package submission
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
 )
 
+const (
+	ReconcileStatusInSync  = "in_sync"
+	ReconcileStatusMissing = "missing"
+	ReconcileStatusStale   = "stale"
+
+	AnswerStatusApplied   = "applied"
+	AnswerStatusStale     = "stale"     // a later sequence of the same question was already applied
+	AnswerStatusDuplicate = "duplicate" // the idempotency key was already applied
+	AnswerStatusInvalid   = "invalid"   // the question is not served to the participant, or the option is not of the question
+
+	FlagStatusApplied = "applied"
+	FlagStatusStale   = "stale" // a later sequence of the same flag was already applied
+)
+
 type Submission struct {
 	lib.BaseModel
 
 	ParticipantID uint
 	QuestionID    uint
 	McqOptionID   uint
+	Sequence      int64
 }
 
-type ExamSessionSubmissionCacheObject struct {
+// SubmissionEvent is an append-only record of an answer change, kept for dispute resolution since submissions only hold the final answer.
+type SubmissionEvent struct {
+	lib.BaseModel
+
+	ParticipantID  uint
+	QuestionID     uint
+	McqOptionID    uint
+	SessionSerial  string
+	Sequence       int64
+	IdempotencyKey string
+	AnsweredAt     time.Time
+}
+
+// SubmissionFlag marks a question the participant is doubtful about ("ragu-ragu"), whether it is answered or not.
+type SubmissionFlag struct {
+	lib.BaseModel
+
 	ParticipantID uint
 	QuestionID    uint
-	McqOptionID   uint
+	IsFlagged     bool
+	Sequence      int64
+}
+
+// ExamSessionFlagCacheObject is the flag as sent by the client, the last writer by Sequence wins like for the answers.
+type ExamSessionFlagCacheObject struct {
+	ParticipantID uint
+	QuestionID    uint
+	IsFlagged     bool
+	Sequence      int64
 	Timestamp     time.Time
 }
 
+func (e *ExamSessionFlagCacheObject) GetKey() string {
+	return fmt.Sprintf("%s:%d:%d", constants.ExamSessionFlagCacheObjectKeyPrefix, e.ParticipantID, e.QuestionID)
+}
+
+// IsNewerThan tells whether the flag should overwrite a stored one, ties on the sequence are broken by the server timestamp.
+func (e *ExamSessionFlagCacheObject) IsNewerThan(flag *SubmissionFlag) bool {
+	if e.Sequence != flag.Sequence {
+		return e.Sequence > flag.Sequence
+	}
+	return flag.UpdatedAt.Before(e.Timestamp.Truncate(time.Second))
+}
+
+// ExamSessionSubmissionCacheObject is the answer as sent by the client, the last writer by Sequence wins both in redis and in the database.
+type ExamSessionSubmissionCacheObject struct {
+	ParticipantID  uint
+	QuestionID     uint
+	McqOptionID    uint
+	SessionSerial  string
+	Sequence       int64
+	IdempotencyKey string
+	Timestamp      time.Time
+}
+
 func (e *ExamSessionSubmissionCacheObject) GetKey() string {
 	return fmt.Sprintf("%s:%d:%d", constants.ExamSessionSubmissionCacheObjectKeyPrefix, e.ParticipantID, e.QuestionID)
 }
+
+func (e *ExamSessionSubmissionCacheObject) GetIdempotencyKey() string {
+	return fmt.Sprintf("%s:%d:%s", constants.ExamSessionSubmissionIdempotencyKeyPrefix, e.ParticipantID, e.IdempotencyKey)
+}
+
+// IsNewerThan tells whether the answer should overwrite a submission, ties on the sequence are broken by the server timestamp.
+func (e *ExamSessionSubmissionCacheObject) IsNewerThan(submission *Submission) bool {
+	if e.Sequence != submission.Sequence {
+		return e.Sequence > submission.Sequence
+	}
+	return submission.UpdatedAt.Before(e.Timestamp.Truncate(time.Second))
+}
+
+func GetCacheObjectKeyPattern() string {
+	return fmt.Sprintf("%s:*", constants.ExamSessionSubmissionCacheObjectKeyPrefix)
+}
+
+// ParseCacheObjectKey reads the participant id and question id back from a key made by GetKey.
+func ParseCacheObjectKey(key string) (participantID uint, questionID uint, ok bool) {
+	parts := strings.Split(key, ":")
+	if len(parts) != 3 || parts[0] != constants.ExamSessionSubmissionCacheObjectKeyPrefix {
+		return 0, 0, false
+	}
+	parsedParticipantID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	parsedQuestionID, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uint(parsedParticipantID), uint(parsedQuestionID), true
+}
@@ -4,20 +4,71 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
 
 	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
 	redis "github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
 	GetSubmissionByParticipantIDAndQuestionID(participantID uint, questionID uint) (*Submission, error)
-	SaveCacheObject(cacheObject *ExamSessionSubmissionCacheObject) error
+	GetSubmissionsInDBByParticipantID(participantID uint) ([]*Submission, error)
+	GetSubmissionsByParticipantIDAndQuestionIDs(participantID uint, questionIDs []uint) (map[uint]*Submission, error)
+	SaveCacheObject(cacheObject *ExamSessionSubmissionCacheObject) (string, error)
 	UpsertSubmissionInDB(cacheObject *ExamSessionSubmissionCacheObject) error
+	IsParticipantEnded(participantID uint) (bool, error)
+	DeleteCacheObjectByKey(key string) error
+	GetFlagByParticipantIDAndQuestionID(participantID uint, questionID uint) (*SubmissionFlag, error)
+	GetFlagsByParticipantIDAndQuestionIDs(participantID uint, questionIDs []uint) (map[uint]*SubmissionFlag, error)
+	SaveFlagCacheObject(cacheObject *ExamSessionFlagCacheObject) (string, error)
+	GetFlagCacheObjectByKey(key string) (*ExamSessionFlagCacheObject, error)
+	UpsertFlagInDB(cacheObject *ExamSessionFlagCacheObject) error
+	CreateSubmissionEvent(event *SubmissionEvent) error
+	GetSubmissionEventsByParticipantID(participantID uint) ([]*SubmissionEvent, error)
+	GetCacheObjectKeys() ([]string, error)
+	GetCacheObjectByKey(key string) (*ExamSessionSubmissionCacheObject, error)
+	GetReconcileStatus(cacheObject *ExamSessionSubmissionCacheObject) (string, error)
+	GetCacheObjectsByParticipantIDsAndQuestionIDs(participantIDs []uint, questionIDs []uint) ([]*ExamSessionSubmissionCacheObject, error)
+	GetSubmissionsInDBByParticipantIDs(participantIDs []uint) ([]*Submission, error)
 }
 
+// saveCacheObjectScript sets the answer or flag only when its sequence is later than the cached one, and remembers its idempotency key.
+// When it is not cached, the sequence persisted in the database is compared instead, and 'miss' is returned while it is not given yet.
+// KEYS[1] is the cache key, KEYS[2] is the idempotency key or empty, ARGV[1] is the cache object, ARGV[2] its sequence, ARGV[3] the ttl in milliseconds,
+// ARGV[4] the persisted sequence, -1 when nothing is persisted, or empty when it is not read yet.
+var saveCacheObjectScript = redis.NewScript(`
+if KEYS[2] ~= '' and redis.call('EXISTS', KEYS[2]) == 1 then
+	return 'duplicate'
+end
+local current = redis.call('GET', KEYS[1])
+if current then
+	if current ~= 'none' then
+		local ok, decoded = pcall(cjson.decode, current)
+		if ok and tonumber(decoded['Sequence'] or 0) > tonumber(ARGV[2]) then
+			return 'stale'
+		end
+	end
+else
+	if ARGV[4] == '' then
+		return 'miss'
+	end
+	if tonumber(ARGV[4]) > tonumber(ARGV[2]) then
+		return 'stale'
+	end
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+if KEYS[2] ~= '' then
+	redis.call('SET', KEYS[2], '1', 'PX', ARGV[3])
+end
+return 'applied'
+`)
+
+const saveCacheObjectStatusMiss = "miss"
+
 type repository struct {
 	cfg   *config.Config
 	db    *gorm.DB
@@ -57,7 +108,8 @@ func (r *repository) GetSubmissionByParticipantIDAndQuestionID(participantID uin
 	err = r.db.Where("participant_id = ? AND question_id = ? AND not_archived", participantID, questionID).First(&submission).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			r.cache.Set(context.Background(), cacheKey, []byte(constants.None), r.cfg.CacheTTL)
+			// never overwrites an answer saved in the meantime
+			r.cache.SetNX(context.Background(), cacheKey, []byte(constants.None), r.cfg.CacheTTL)
 			return nil, lib.ErrSubmissionNotFound
 		}
 		return nil, err
@@ -67,23 +119,169 @@ func (r *repository) GetSubmissionByParticipantIDAndQuestionID(participantID uin
 		ParticipantID: submission.ParticipantID,
 		QuestionID:    submission.QuestionID,
 		McqOptionID:   submission.McqOptionID,
+		Sequence:      submission.Sequence,
 		Timestamp:     submission.UpdatedAt,
 	})
-	r.cache.Set(context.Background(), cacheKey, res, r.cfg.CacheTTL)
+	r.cache.SetNX(context.Background(), cacheKey, res, r.cfg.CacheTTL)
 	return &submission, nil
 }
 
-func (r *repository) SaveCacheObject(cacheObject *ExamSessionSubmissionCacheObject) error {
+func (r *repository) GetSubmissionsInDBByParticipantID(participantID uint) ([]*Submission, error) {
+	var res []*Submission
+	err := r.db.Where("participant_id = ? AND not_archived", participantID).Order("question_id ASC").Find(&res).Error
+	return res, err
+}
+
+// GetSubmissionsByParticipantIDAndQuestionIDs reads the answers from the cache in one round trip, and the misses from the database in one query.
+// Unanswered questions are left out of the result.
+func (r *repository) GetSubmissionsByParticipantIDAndQuestionIDs(participantID uint, questionIDs []uint) (map[uint]*Submission, error) {
+	res := map[uint]*Submission{}
+	if len(questionIDs) == 0 {
+		return res, nil
+	}
+
+	cacheKeys := []string{}
+	for _, questionID := range questionIDs {
+		cacheKeys = append(cacheKeys, (&ExamSessionSubmissionCacheObject{
+			ParticipantID: participantID,
+			QuestionID:    questionID,
+		}).GetKey())
+	}
+
+	vals, err := r.cache.MGet(context.Background(), cacheKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	missedQuestionIDs := []uint{}
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			missedQuestionIDs = append(missedQuestionIDs, questionIDs[i])
+			continue
+		}
+		if str == constants.None {
+			continue
+		}
+		var submission Submission
+		json.Unmarshal([]byte(str), &submission)
+		res[questionIDs[i]] = &submission
+	}
+	if len(missedQuestionIDs) == 0 {
+		return res, nil
+	}
+
+	var submissions []*Submission
+	err = r.db.Where("participant_id = ? AND question_id IN ? AND not_archived", participantID, missedQuestionIDs).Find(&submissions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[uint]*Submission{}
+	for _, submission := range submissions {
+		found[submission.QuestionID] = submission
+		res[submission.QuestionID] = submission
+	}
+	pipe := r.cache.Pipeline()
+	for _, questionID := range missedQuestionIDs {
+		cacheKey := (&ExamSessionSubmissionCacheObject{
+			ParticipantID: participantID,
+			QuestionID:    questionID,
+		}).GetKey()
+		submission, ok := found[questionID]
+		if !ok {
+			pipe.SetNX(context.Background(), cacheKey, []byte(constants.None), r.cfg.CacheTTL)
+			continue
+		}
+		val, _ := json.Marshal(&ExamSessionSubmissionCacheObject{
+			ParticipantID: submission.ParticipantID,
+			QuestionID:    submission.QuestionID,
+			McqOptionID:   submission.McqOptionID,
+			Sequence:      submission.Sequence,
+			Timestamp:     submission.UpdatedAt,
+		})
+		pipe.SetNX(context.Background(), cacheKey, val, r.cfg.CacheTTL)
+	}
+	pipe.Exec(context.Background())
+	return res, nil
+}
+
+func (r *repository) SaveCacheObject(cacheObject *ExamSessionSubmissionCacheObject) (string, error) {
+	idempotencyKey := ""
+	if cacheObject.IdempotencyKey != "" {
+		idempotencyKey = cacheObject.GetIdempotencyKey()
+	}
+	return r.saveSequencedCacheObject(cacheObject.GetKey(), idempotencyKey, cacheObject, cacheObject.Sequence, func() (int64, error) {
+		var submission Submission
+		err := r.db.Where("participant_id = ? AND question_id = ? AND not_archived", cacheObject.ParticipantID, cacheObject.QuestionID).First(&submission).Error
+		return submission.Sequence, err
+	})
+}
+
+// saveSequencedCacheObject runs saveCacheObjectScript, and once more with the persisted sequence when the key is not cached.
+// getPersistedSequence returns gorm.ErrRecordNotFound when nothing is persisted yet.
+func (r *repository) saveSequencedCacheObject(key string, idempotencyKey string, cacheObject interface{}, sequence int64, getPersistedSequence func() (int64, error)) (string, error) {
 	res, _ := json.Marshal(cacheObject)
-	return r.cache.Set(context.Background(), cacheObject.GetKey(), res, r.cfg.CacheTTL).Err()
+	run := func(persistedSequence string) (string, error) {
+		return saveCacheObjectScript.Run(
+			context.Background(),
+			r.cache,
+			[]string{key, idempotencyKey},
+			string(res),
+			sequence,
+			r.cfg.CacheTTL.Milliseconds(),
+			persistedSequence,
+		).Text()
+	}
+
+	status, err := run("")
+	if err != nil || status != saveCacheObjectStatusMiss {
+		return status, err
+	}
+
+	// the cached object expired or was evicted, so the persisted one keeps an older one from being cached
+	persistedSequence, err := getPersistedSequence()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		persistedSequence = -1
+	} else if err != nil {
+		return "", err
+	}
+	return run(strconv.FormatInt(persistedSequence, 10))
 }
 
+// UpsertSubmissionInDB refuses with lib.ErrAnswersFrozen once the participant has a submission receipt.
+// The participant row is share locked while writing, and the receipt is created under an exclusive lock of the same row,
+// so an answer is either written before the receipt hashes the answers or not at all.
 func (r *repository) UpsertSubmissionInDB(cacheObject *ExamSessionSubmissionCacheObject) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var participantIDs []uint
+		err := tx.Table("participants").
+			Clauses(clause.Locking{Strength: "SHARE"}).
+			Where("id = ?", cacheObject.ParticipantID).
+			Pluck("id", &participantIDs).Error
+		if err != nil {
+			return err
+		}
+		var receiptCount int64
+		err = tx.Table("submission_receipts").
+			Where("participant_id = ? AND not_archived", cacheObject.ParticipantID).
+			Count(&receiptCount).Error
+		if err != nil {
+			return err
+		}
+		if receiptCount > 0 {
+			return lib.ErrAnswersFrozen
+		}
+		return r.upsertSubmission(tx, cacheObject)
+	})
+}
+
+func (r *repository) upsertSubmission(tx *gorm.DB, cacheObject *ExamSessionSubmissionCacheObject) error {
 	var submission Submission
-	err := r.db.Where("participant_id = ? AND question_id = ? AND not_archived", cacheObject.ParticipantID, cacheObject.QuestionID).First(&submission).Error
+	err := tx.Where("participant_id = ? AND question_id = ? AND not_archived", cacheObject.ParticipantID, cacheObject.QuestionID).First(&submission).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return r.db.Create(&Submission{
+			return tx.Create(&Submission{
 				BaseModel: lib.BaseModel{
 					Model: gorm.Model{
 						CreatedAt: cacheObject.Timestamp,
@@ -93,17 +291,312 @@ func (r *repository) UpsertSubmissionInDB(cacheObject *ExamSessionSubmissionCach
 				ParticipantID: cacheObject.ParticipantID,
 				QuestionID:    cacheObject.QuestionID,
 				McqOptionID:   cacheObject.McqOptionID,
+				Sequence:      cacheObject.Sequence,
 			}).Error
 		}
 		return err
 	}
 
-	if submission.UpdatedAt != cacheObject.Timestamp {
-		return r.db.Model(submission).Where("participant_id = ? AND question_id = ? AND not_archived", cacheObject.ParticipantID, cacheObject.QuestionID).Updates(
+	if cacheObject.IsNewerThan(&submission) {
+		// the condition is repeated in the query, so a concurrent older answer cannot win
+		return tx.Model(submission).Where(
+			"id = ? AND (sequence < ? OR (sequence = ? AND updated_at < ?))",
+			submission.ID, cacheObject.Sequence, cacheObject.Sequence, cacheObject.Timestamp,
+		).Updates(
 			map[string]interface{}{
 				"mcq_option_id": cacheObject.McqOptionID,
+				"sequence":      cacheObject.Sequence,
 				"updated_at":    cacheObject.Timestamp,
 			}).Error
 	}
 	return nil
 }
+
+func (r *repository) IsParticipantEnded(participantID uint) (bool, error) {
+	var count int64
+	err := r.db.Table("participants").Where("id = ? AND ended_at IS NOT NULL", participantID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) DeleteCacheObjectByKey(key string) error {
+	return r.cache.Del(context.Background(), key).Err()
+}
+
+func (r *repository) GetFlagByParticipantIDAndQuestionID(participantID uint, questionID uint) (*SubmissionFlag, error) {
+	var flag SubmissionFlag
+
+	cacheKey := (&ExamSessionFlagCacheObject{
+		ParticipantID: participantID,
+		QuestionID:    questionID,
+	}).GetKey()
+
+	val, err := r.cache.Get(context.Background(), cacheKey).Result()
+	if err == nil {
+		if string(val) != constants.None {
+			json.Unmarshal([]byte(val), &flag)
+			return &flag, nil
+		} else {
+			return nil, lib.ErrSubmissionFlagNotFound
+		}
+	}
+
+	err = r.db.Where("participant_id = ? AND question_id = ? AND not_archived", participantID, questionID).First(&flag).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.cache.SetNX(context.Background(), cacheKey, []byte(constants.None), r.cfg.CacheTTL)
+			return nil, lib.ErrSubmissionFlagNotFound
+		}
+		return nil, err
+	}
+
+	res, _ := json.Marshal(&ExamSessionFlagCacheObject{
+		ParticipantID: flag.ParticipantID,
+		QuestionID:    flag.QuestionID,
+		IsFlagged:     flag.IsFlagged,
+		Sequence:      flag.Sequence,
+		Timestamp:     flag.UpdatedAt,
+	})
+	r.cache.SetNX(context.Background(), cacheKey, res, r.cfg.CacheTTL)
+	return &flag, nil
+}
+
+// GetFlagsByParticipantIDAndQuestionIDs works like GetSubmissionsByParticipantIDAndQuestionIDs, for the flags.
+func (r *repository) GetFlagsByParticipantIDAndQuestionIDs(participantID uint, questionIDs []uint) (map[uint]*SubmissionFlag, error) {
+	res := map[uint]*SubmissionFlag{}
+	if len(questionIDs) == 0 {
+		return res, nil
+	}
+
+	cacheKeys := []string{}
+	for _, questionID := range questionIDs {
+		cacheKeys = append(cacheKeys, (&ExamSessionFlagCacheObject{
+			ParticipantID: participantID,
+			QuestionID:    questionID,
+		}).GetKey())
+	}
+
+	vals, err := r.cache.MGet(context.Background(), cacheKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	missedQuestionIDs := []uint{}
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			missedQuestionIDs = append(missedQuestionIDs, questionIDs[i])
+			continue
+		}
+		if str == constants.None {
+			continue
+		}
+		var flag SubmissionFlag
+		json.Unmarshal([]byte(str), &flag)
+		res[questionIDs[i]] = &flag
+	}
+	if len(missedQuestionIDs) == 0 {
+		return res, nil
+	}
+
+	var flags []*SubmissionFlag
+	err = r.db.Where("participant_id = ? AND question_id IN ? AND not_archived", participantID, missedQuestionIDs).Find(&flags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[uint]*SubmissionFlag{}
+	for _, flag := range flags {
+		found[flag.QuestionID] = flag
+		res[flag.QuestionID] = flag
+	}
+	pipe := r.cache.Pipeline()
+	for _, questionID := range missedQuestionIDs {
+		cacheKey := (&ExamSessionFlagCacheObject{
+			ParticipantID: participantID,
+			QuestionID:    questionID,
+		}).GetKey()
+		flag, ok := found[questionID]
+		if !ok {
+			pipe.SetNX(context.Background(), cacheKey, []byte(constants.None), r.cfg.CacheTTL)
+			continue
+		}
+		val, _ := json.Marshal(&ExamSessionFlagCacheObject{
+			ParticipantID: flag.ParticipantID,
+			QuestionID:    flag.QuestionID,
+			IsFlagged:     flag.IsFlagged,
+			Sequence:      flag.Sequence,
+			Timestamp:     flag.UpdatedAt,
+		})
+		pipe.SetNX(context.Background(), cacheKey, val, r.cfg.CacheTTL)
+	}
+	pipe.Exec(context.Background())
+	return res, nil
+}
+
+func (r *repository) SaveFlagCacheObject(cacheObject *ExamSessionFlagCacheObject) (string, error) {
+	return r.saveSequencedCacheObject(cacheObject.GetKey(), "", cacheObject, cacheObject.Sequence, func() (int64, error) {
+		var flag SubmissionFlag
+		err := r.db.Where("participant_id = ? AND question_id = ? AND not_archived", cacheObject.ParticipantID, cacheObject.QuestionID).First(&flag).Error
+		return flag.Sequence, err
+	})
+}
+
+func (r *repository) GetFlagCacheObjectByKey(key string) (*ExamSessionFlagCacheObject, error) {
+	val, err := r.cache.Get(context.Background(), key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, lib.ErrSubmissionFlagNotFound
+		}
+		return nil, err
+	}
+	// a cached miss of the database is not a flag
+	if val == constants.None {
+		return nil, lib.ErrSubmissionFlagNotFound
+	}
+	var cacheObject ExamSessionFlagCacheObject
+	err = json.Unmarshal([]byte(val), &cacheObject)
+	if err != nil {
+		return nil, lib.ErrInvalidCachedFlag
+	}
+	return &cacheObject, nil
+}
+
+func (r *repository) UpsertFlagInDB(cacheObject *ExamSessionFlagCacheObject) error {
+	var flag SubmissionFlag
+	err := r.db.Where("participant_id = ? AND question_id = ? AND not_archived", cacheObject.ParticipantID, cacheObject.QuestionID).First(&flag).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return r.db.Create(&SubmissionFlag{
+				BaseModel: lib.BaseModel{
+					Model: gorm.Model{
+						CreatedAt: cacheObject.Timestamp,
+						UpdatedAt: cacheObject.Timestamp,
+					},
+				},
+				ParticipantID: cacheObject.ParticipantID,
+				QuestionID:    cacheObject.QuestionID,
+				IsFlagged:     cacheObject.IsFlagged,
+				Sequence:      cacheObject.Sequence,
+			}).Error
+		}
+		return err
+	}
+
+	if cacheObject.IsNewerThan(&flag) {
+		// the condition is repeated in the query, so a concurrent older flag cannot win
+		return r.db.Model(flag).Where(
+			"id = ? AND (sequence < ? OR (sequence = ? AND updated_at < ?))",
+			flag.ID, cacheObject.Sequence, cacheObject.Sequence, cacheObject.Timestamp,
+		).Updates(
+			map[string]interface{}{
+				"is_flagged": cacheObject.IsFlagged,
+				"sequence":   cacheObject.Sequence,
+				"updated_at": cacheObject.Timestamp,
+			}).Error
+	}
+	return nil
+}
+
+// CreateSubmissionEvent skips an event which is already recorded, since a redelivered answer change publishes the same event again.
+// Events of answers without a sequence, recorded before the answers were sequenced, are not deduplicated.
+func (r *repository) CreateSubmissionEvent(event *SubmissionEvent) error {
+	err := r.db.Create(event).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil
+	}
+	return err
+}
+
+func (r *repository) GetSubmissionEventsByParticipantID(participantID uint) ([]*SubmissionEvent, error) {
+	var res []*SubmissionEvent
+	err := r.db.Where("participant_id = ?", participantID).Order("answered_at ASC, id ASC").Find(&res).Error
+	return res, err
+}
+
+func (r *repository) GetCacheObjectKeys() ([]string, error) {
+	res := []string{}
+	iter := r.cache.Scan(context.Background(), 0, GetCacheObjectKeyPattern(), constants.CacheScanCount).Iterator()
+	for iter.Next(context.Background()) {
+		res = append(res, iter.Val())
+	}
+	return res, iter.Err()
+}
+
+func (r *repository) GetCacheObjectByKey(key string) (*ExamSessionSubmissionCacheObject, error) {
+	val, err := r.cache.Get(context.Background(), key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, lib.ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+	// a cached miss of the database is not an answer
+	if val == constants.None {
+		return nil, lib.ErrSubmissionNotFound
+	}
+	var cacheObject ExamSessionSubmissionCacheObject
+	err = json.Unmarshal([]byte(val), &cacheObject)
+	if err != nil {
+		return nil, lib.ErrInvalidCachedAnswer
+	}
+	return &cacheObject, nil
+}
+
+func (r *repository) GetReconcileStatus(cacheObject *ExamSessionSubmissionCacheObject) (string, error) {
+	var submission Submission
+	err := r.db.Where("participant_id = ? AND question_id = ? AND not_archived", cacheObject.ParticipantID, cacheObject.QuestionID).First(&submission).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ReconcileStatusMissing, nil
+		}
+		return "", err
+	}
+	if cacheObject.IsNewerThan(&submission) {
+		return ReconcileStatusStale, nil
+	}
+	return ReconcileStatusInSync, nil
+}
+
+// GetCacheObjectsByParticipantIDsAndQuestionIDs reads the cached answers of every given participant and question pair without scanning the keyspace.
+// Pairs which are not cached or cached as unanswered are left out.
+func (r *repository) GetCacheObjectsByParticipantIDsAndQuestionIDs(participantIDs []uint, questionIDs []uint) ([]*ExamSessionSubmissionCacheObject, error) {
+	keys := []string{}
+	for _, participantID := range participantIDs {
+		for _, questionID := range questionIDs {
+			keys = append(keys, (&ExamSessionSubmissionCacheObject{
+				ParticipantID: participantID,
+				QuestionID:    questionID,
+			}).GetKey())
+		}
+	}
+
+	res := []*ExamSessionSubmissionCacheObject{}
+	for start := 0; start < len(keys); start += constants.CacheScanCount {
+		end := min(start+constants.CacheScanCount, len(keys))
+		vals, err := r.cache.MGet(context.Background(), keys[start:end]...).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, val := range vals {
+			str, ok := val.(string)
+			if !ok || str == constants.None {
+				continue
+			}
+			var cacheObject ExamSessionSubmissionCacheObject
+			if err := json.Unmarshal([]byte(str), &cacheObject); err != nil {
+				continue
+			}
+			res = append(res, &cacheObject)
+		}
+	}
+	return res, nil
+}
+
+func (r *repository) GetSubmissionsInDBByParticipantIDs(participantIDs []uint) ([]*Submission, error) {
+	var res []*Submission
+	if len(participantIDs) == 0 {
+		return res, nil
+	}
+	err := r.db.Where("participant_id IN ? AND not_archived", participantIDs).Find(&res).Error
+	return res, err
+}
@@ -0,0 +1,79 @@
+package submission
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func newCacheRepository(t *testing.T) *repository {
+	t.Helper()
+	server := miniredis.RunT(t)
+	return &repository{
+		cfg:   &config.Config{CacheTTL: time.Hour},
+		cache: redis.NewClient(&redis.Options{Addr: server.Addr()}),
+	}
+}
+
+func persistedSequence(sequence int64, err error) func() (int64, error) {
+	return func() (int64, error) {
+		return sequence, err
+	}
+}
+
+func TestSaveSequencedCacheObject(t *testing.T) {
+	const key = "submission:1:2"
+	save := func(r *repository, idempotencyKey string, sequence int64, getPersistedSequence func() (int64, error)) string {
+		t.Helper()
+		status, err := r.saveSequencedCacheObject(key, idempotencyKey, &ExamSessionSubmissionCacheObject{Sequence: sequence}, sequence, getPersistedSequence)
+		if err != nil {
+			t.Fatalf("saveSequencedCacheObject() error = %v", err)
+		}
+		return status
+	}
+
+	t.Run("applies later sequences and refuses earlier ones", func(t *testing.T) {
+		r := newCacheRepository(t)
+		if status := save(r, "", 2, persistedSequence(0, gorm.ErrRecordNotFound)); status != "applied" {
+			t.Fatalf("first save = %q, want applied", status)
+		}
+		if status := save(r, "", 1, nil); status != "stale" {
+			t.Fatalf("earlier save = %q, want stale", status)
+		}
+		if status := save(r, "", 3, nil); status != "applied" {
+			t.Fatalf("later save = %q, want applied", status)
+		}
+	})
+
+	t.Run("refuses a used idempotency key", func(t *testing.T) {
+		r := newCacheRepository(t)
+		if status := save(r, "idempotency:a", 1, persistedSequence(0, gorm.ErrRecordNotFound)); status != "applied" {
+			t.Fatalf("first save = %q, want applied", status)
+		}
+		if status := save(r, "idempotency:a", 2, nil); status != "duplicate" {
+			t.Fatalf("repeated save = %q, want duplicate", status)
+		}
+	})
+
+	t.Run("compares the persisted sequence when not cached", func(t *testing.T) {
+		r := newCacheRepository(t)
+		if status := save(r, "", 4, persistedSequence(5, nil)); status != "stale" {
+			t.Fatalf("save before the persisted sequence = %q, want stale", status)
+		}
+		if status := save(r, "", 6, persistedSequence(5, nil)); status != "applied" {
+			t.Fatalf("save after the persisted sequence = %q, want applied", status)
+		}
+	})
+
+	t.Run("returns the persisted sequence error", func(t *testing.T) {
+		r := newCacheRepository(t)
+		_, err := r.saveSequencedCacheObject(key, "", &ExamSessionSubmissionCacheObject{Sequence: 1}, 1, persistedSequence(0, gorm.ErrInvalidDB))
+		if err != gorm.ErrInvalidDB {
+			t.Fatalf("saveSequencedCacheObject() error = %v, want %v", err, gorm.ErrInvalidDB)
+		}
+	})
+}
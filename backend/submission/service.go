@@ -1,48 +1,83 @@
 package submission
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"log"
 
-	rmq "github.com/adjust/rmq/v5"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/client/queue"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
 	redis "github.com/redis/go-redis/v9"
 )
 
 type Service interface {
-	Answer(cacheObject *ExamSessionSubmissionCacheObject) error
-	UpsertSubmissionInDB(key string) error
+	Answer(cacheObject *ExamSessionSubmissionCacheObject) (string, error)
+	UpsertSubmissionInDB(payload string) (*ExamSessionSubmissionCacheObject, error)
 	GetAnswer(participantID uint, questionID uint) (*Submission, error)
+	GetAnswers(participantID uint, questionIDs []uint) (map[uint]*Submission, error)
+	GetAnswerHistory(participantID uint) ([]*SubmissionEvent, error)
+	FlushAnswers(participantID uint, questionIDs []uint) error
+	GetAnswersInDB(participantID uint) ([]*Submission, error)
+	Flag(cacheObject *ExamSessionFlagCacheObject) (string, error)
+	UpsertFlagInDB(payload string) (*ExamSessionFlagCacheObject, error)
+	IsFlagged(participantID uint, questionID uint) (bool, error)
+	GetFlags(participantID uint, questionIDs []uint) (map[uint]*SubmissionFlag, error)
+	GetCacheObjectKeys() ([]string, error)
+	ReconcileCacheObject(key string, dryRun bool) (*ExamSessionSubmissionCacheObject, string, error)
+	GetCacheObjectStatuses(participantIDs []uint, questionIDs []uint) (map[string]string, error)
 }
 
 type service struct {
 	submissionRepository Repository
 	redisClient          *redis.Client
-	updateAnswerQueue    rmq.Queue
+	updateAnswerQueue    queue.Queue
+	updateFlagQueue      queue.Queue
 }
 
 func NewService(
 	submissionRepository Repository,
 	redisClient *redis.Client,
-	updateAnswerQueue rmq.Queue,
+	updateAnswerQueue queue.Queue,
+	updateFlagQueue queue.Queue,
 ) Service {
 	return &service{
 		submissionRepository: submissionRepository,
 		redisClient:          redisClient,
 		updateAnswerQueue:    updateAnswerQueue,
+		updateFlagQueue:      updateFlagQueue,
 	}
 }
 
-func (s *service) Answer(cacheObject *ExamSessionSubmissionCacheObject) error {
-	err := s.submissionRepository.SaveCacheObject(cacheObject)
+// Answer saves the answer unless a later sequence was already saved or its idempotency key was already used, and returns which one happened.
+// The answers of an ended participant are frozen, so lib.ErrExamAlreadySubmitted is returned for them.
+func (s *service) Answer(cacheObject *ExamSessionSubmissionCacheObject) (string, error) {
+	isEnded, err := s.submissionRepository.IsParticipantEnded(cacheObject.ParticipantID)
+	if err != nil {
+		log.Println("[submission][service][Answer] failed to check participant:", err.Error())
+		return "", lib.ErrFailedToSaveAnswer
+	}
+	if isEnded {
+		return "", lib.ErrExamAlreadySubmitted
+	}
+
+	status, err := s.submissionRepository.SaveCacheObject(cacheObject)
 	if err != nil {
 		log.Println("[submission][service][Answer] failed to save answer:", err.Error())
-		return lib.ErrFailedToSaveAnswer
+		return "", lib.ErrFailedToSaveAnswer
 	}
-	s.updateAnswerQueue.Publish(cacheObject.GetKey())
-	return nil
+	if status != AnswerStatusApplied {
+		return status, nil
+	}
+	// the whole answer is published rather than its key, so the worker still sees it after a later answer overwrote the cache
+	payload, _ := json.Marshal(cacheObject)
+	if err := s.updateAnswerQueue.Publish(string(payload)); err != nil {
+		// the answer is only cached now, so it is written to the database right away, or by the periodic reconciliation when that fails too
+		log.Println("[submission][service][Answer] failed to publish answer, writing it directly:", err.Error())
+		if _, err := s.UpsertSubmissionInDB(string(payload)); err != nil {
+			log.Println("[submission][service][Answer] failed to write answer:", err.Error())
+		}
+	}
+	return status, nil
 }
 
 func (s *service) GetAnswer(participantID uint, questionID uint) (*Submission, error) {
@@ -57,12 +92,244 @@ func (s *service) GetAnswer(participantID uint, questionID uint) (*Submission, e
 	return res, nil
 }
 
-func (s *service) UpsertSubmissionInDB(key string) error {
-	val, err := s.redisClient.Get(context.Background(), key).Result()
+// GetAnswers returns the answers of the given questions by question id, unanswered questions are left out.
+func (s *service) GetAnswers(participantID uint, questionIDs []uint) (map[uint]*Submission, error) {
+	res, err := s.submissionRepository.GetSubmissionsByParticipantIDAndQuestionIDs(participantID, questionIDs)
+	if err != nil {
+		log.Println("[submission][service][GetAnswers] failed to get answers:", err.Error())
+		return nil, lib.ErrFailedToGetAnswer
+	}
+	return res, nil
+}
+
+// FlushAnswers writes the cached answers of the given questions into the database without waiting for the queue.
+func (s *service) FlushAnswers(participantID uint, questionIDs []uint) error {
+	for _, questionID := range questionIDs {
+		key := (&ExamSessionSubmissionCacheObject{
+			ParticipantID: participantID,
+			QuestionID:    questionID,
+		}).GetKey()
+		_, _, err := s.ReconcileCacheObject(key, false)
+		if err != nil {
+			return lib.ErrFailedToFlushAnswers
+		}
+	}
+	return nil
+}
+
+// GetAnswersInDB returns the answers of the participant ordered by question id, read from the database only.
+func (s *service) GetAnswersInDB(participantID uint) ([]*Submission, error) {
+	res, err := s.submissionRepository.GetSubmissionsInDBByParticipantID(participantID)
+	if err != nil {
+		log.Println("[submission][service][GetAnswersInDB] failed to get answers:", err.Error())
+		return nil, lib.ErrFailedToGetAnswer
+	}
+	return res, nil
+}
+
+func (s *service) GetAnswerHistory(participantID uint) ([]*SubmissionEvent, error) {
+	res, err := s.submissionRepository.GetSubmissionEventsByParticipantID(participantID)
 	if err != nil {
-		return err
+		log.Println("[submission][service][GetAnswerHistory] failed to get submission events:", err.Error())
+		return nil, lib.ErrFailedToGetAnswerHistory
+	}
+	return res, nil
+}
+
+// UpsertSubmissionInDB writes the latest cached answer into the database and records the published answer change.
+// When the answer is no longer cached, the published answer is written instead, the database keeps whichever is later.
+// Payloads published before the answer changes were recorded only hold the cache key, those are upserted without an event,
+// and fail with lib.ErrSubmissionNotFound or lib.ErrInvalidCachedAnswer once the key is gone, which a retry cannot fix.
+func (s *service) UpsertSubmissionInDB(payload string) (*ExamSessionSubmissionCacheObject, error) {
+	var published ExamSessionSubmissionCacheObject
+	key := payload
+	isAnswerChange := json.Unmarshal([]byte(payload), &published) == nil
+	if isAnswerChange {
+		key = published.GetKey()
+	}
+
+	cacheObject, err := s.submissionRepository.GetCacheObjectByKey(key)
+	if err != nil {
+		isGone := errors.Is(err, lib.ErrSubmissionNotFound) || errors.Is(err, lib.ErrInvalidCachedAnswer)
+		if !isAnswerChange || !isGone {
+			return nil, err
+		}
+		cacheObject = &published
+	}
+	err = s.submissionRepository.UpsertSubmissionInDB(cacheObject)
+	if err != nil {
+		if errors.Is(err, lib.ErrAnswersFrozen) {
+			s.dropFrozenCacheObject(key)
+		}
+		return nil, err
+	}
+
+	if isAnswerChange {
+		err = s.submissionRepository.CreateSubmissionEvent(&SubmissionEvent{
+			ParticipantID:  published.ParticipantID,
+			QuestionID:     published.QuestionID,
+			McqOptionID:    published.McqOptionID,
+			SessionSerial:  published.SessionSerial,
+			Sequence:       published.Sequence,
+			IdempotencyKey: published.IdempotencyKey,
+			AnsweredAt:     published.Timestamp,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cacheObject, nil
+}
+
+// Flag saves the flag like an answer, unless a later sequence of it was already saved, and returns which one happened.
+func (s *service) Flag(cacheObject *ExamSessionFlagCacheObject) (string, error) {
+	status, err := s.submissionRepository.SaveFlagCacheObject(cacheObject)
+	if err != nil {
+		log.Println("[submission][service][Flag] failed to save flag:", err.Error())
+		return "", lib.ErrFailedToSaveFlag
+	}
+	if status != FlagStatusApplied {
+		return status, nil
+	}
+	payload, _ := json.Marshal(cacheObject)
+	if err := s.updateFlagQueue.Publish(string(payload)); err != nil {
+		// the flag is only cached now, so it is written to the database right away
+		log.Println("[submission][service][Flag] failed to publish flag, writing it directly:", err.Error())
+		if _, err := s.UpsertFlagInDB(string(payload)); err != nil {
+			log.Println("[submission][service][Flag] failed to write flag:", err.Error())
+		}
+	}
+	return status, nil
+}
+
+// UpsertFlagInDB writes the latest cached flag into the database, or the published flag when it is no longer cached.
+// Payloads published before the flags were sequenced only hold the cache key,
+// and fail with lib.ErrSubmissionFlagNotFound or lib.ErrInvalidCachedFlag once the key is gone, which a retry cannot fix.
+func (s *service) UpsertFlagInDB(payload string) (*ExamSessionFlagCacheObject, error) {
+	var published ExamSessionFlagCacheObject
+	key := payload
+	isFlagChange := json.Unmarshal([]byte(payload), &published) == nil
+	if isFlagChange {
+		key = published.GetKey()
 	}
-	var cacheObject ExamSessionSubmissionCacheObject
-	json.Unmarshal([]byte(val), &cacheObject)
-	return s.submissionRepository.UpsertSubmissionInDB(&cacheObject)
+
+	cacheObject, err := s.submissionRepository.GetFlagCacheObjectByKey(key)
+	if err != nil {
+		isGone := errors.Is(err, lib.ErrSubmissionFlagNotFound) || errors.Is(err, lib.ErrInvalidCachedFlag)
+		if !isFlagChange || !isGone {
+			return nil, err
+		}
+		cacheObject = &published
+	}
+	return cacheObject, s.submissionRepository.UpsertFlagInDB(cacheObject)
+}
+
+func (s *service) IsFlagged(participantID uint, questionID uint) (bool, error) {
+	res, err := s.submissionRepository.GetFlagByParticipantIDAndQuestionID(participantID, questionID)
+	if err != nil {
+		if errors.Is(err, lib.ErrSubmissionFlagNotFound) {
+			return false, nil
+		}
+		log.Println("[submission][service][IsFlagged] failed to get flag:", err.Error())
+		return false, lib.ErrFailedToGetFlag
+	}
+	return res.IsFlagged, nil
+}
+
+// GetFlags returns the flags of the given questions by question id, questions never flagged are left out.
+func (s *service) GetFlags(participantID uint, questionIDs []uint) (map[uint]*SubmissionFlag, error) {
+	res, err := s.submissionRepository.GetFlagsByParticipantIDAndQuestionIDs(participantID, questionIDs)
+	if err != nil {
+		log.Println("[submission][service][GetFlags] failed to get flags:", err.Error())
+		return nil, lib.ErrFailedToGetFlag
+	}
+	return res, nil
+}
+
+func (s *service) GetCacheObjectKeys() ([]string, error) {
+	res, err := s.submissionRepository.GetCacheObjectKeys()
+	if err != nil {
+		log.Println("[submission][service][GetCacheObjectKeys] failed to get cache object keys:", err.Error())
+		return nil, lib.ErrFailedToGetCachedAnswers
+	}
+	return res, nil
+}
+
+// ReconcileCacheObject compares the answer cached under key with the database, and writes it to the database when it is missing or stale, unless dryRun is set.
+func (s *service) ReconcileCacheObject(key string, dryRun bool) (*ExamSessionSubmissionCacheObject, string, error) {
+	cacheObject, err := s.submissionRepository.GetCacheObjectByKey(key)
+	if err != nil {
+		if errors.Is(err, lib.ErrSubmissionNotFound) {
+			// expired or not an answer, so there is nothing to reconcile
+			return nil, ReconcileStatusInSync, nil
+		}
+		log.Println("[submission][service][ReconcileCacheObject] failed to get cache object:", err.Error())
+		return nil, "", lib.ErrFailedToReconcileAnswer
+	}
+
+	status, err := s.submissionRepository.GetReconcileStatus(cacheObject)
+	if err != nil {
+		log.Println("[submission][service][ReconcileCacheObject] failed to get reconcile status:", err.Error())
+		return cacheObject, "", lib.ErrFailedToReconcileAnswer
+	}
+	if status == ReconcileStatusInSync || dryRun {
+		return cacheObject, status, nil
+	}
+
+	err = s.submissionRepository.UpsertSubmissionInDB(cacheObject)
+	if err != nil {
+		if errors.Is(err, lib.ErrAnswersFrozen) {
+			s.dropFrozenCacheObject(key)
+			return cacheObject, ReconcileStatusInSync, nil
+		}
+		log.Println("[submission][service][ReconcileCacheObject] failed to upsert submission:", err.Error())
+		return cacheObject, status, lib.ErrFailedToReconcileAnswer
+	}
+	return cacheObject, status, nil
+}
+
+// dropFrozenCacheObject removes an answer cached after the receipt froze the answers, so the cache falls back to the receipted answer.
+func (s *service) dropFrozenCacheObject(key string) {
+	log.Println("[submission][service][dropFrozenCacheObject] dropping answer cached after the receipt:", key)
+	if err := s.submissionRepository.DeleteCacheObjectByKey(key); err != nil {
+		log.Println("[submission][service][dropFrozenCacheObject] failed to delete cache object:", err.Error())
+	}
+}
+
+// GetCacheObjectStatuses compares the cached answers of the given participants and questions with the database in bulk,
+// and returns the reconcile status of each cached answer by its key.
+func (s *service) GetCacheObjectStatuses(participantIDs []uint, questionIDs []uint) (map[string]string, error) {
+	cacheObjects, err := s.submissionRepository.GetCacheObjectsByParticipantIDsAndQuestionIDs(participantIDs, questionIDs)
+	if err != nil {
+		log.Println("[submission][service][GetCacheObjectStatuses] failed to get cache objects:", err.Error())
+		return nil, lib.ErrFailedToGetCachedAnswers
+	}
+	submissions, err := s.submissionRepository.GetSubmissionsInDBByParticipantIDs(participantIDs)
+	if err != nil {
+		log.Println("[submission][service][GetCacheObjectStatuses] failed to get submissions:", err.Error())
+		return nil, lib.ErrFailedToGetCachedAnswers
+	}
+
+	persisted := map[string]*Submission{}
+	for _, submission := range submissions {
+		persisted[(&ExamSessionSubmissionCacheObject{
+			ParticipantID: submission.ParticipantID,
+			QuestionID:    submission.QuestionID,
+		}).GetKey()] = submission
+	}
+
+	res := map[string]string{}
+	for _, cacheObject := range cacheObjects {
+		key := cacheObject.GetKey()
+		submission, ok := persisted[key]
+		switch {
+		case !ok:
+			res[key] = ReconcileStatusMissing
+		case cacheObject.IsNewerThan(submission):
+			res[key] = ReconcileStatusStale
+		default:
+			res[key] = ReconcileStatusInSync
+		}
+	}
+	return res, nil
 }
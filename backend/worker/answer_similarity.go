@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/analytics"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/client/queue"
+)
+
+type AnswerSimilarityQueueConsumer struct {
+	analyticsService analytics.Service
+}
+
+func NewAnswerSimilarityQueueConsumer(
+	analyticsService analytics.Service,
+) *AnswerSimilarityQueueConsumer {
+	return &AnswerSimilarityQueueConsumer{
+		analyticsService: analyticsService,
+	}
+}
+
+func (consumer *AnswerSimilarityQueueConsumer) Consume(delivery queue.Delivery) {
+	examID, err := strconv.ParseUint(delivery.Payload(), 10, 64)
+	if err != nil {
+		log.Println("[worker][AnswerSimilarityQueueConsumer][Consume] invalid payload", delivery.Payload())
+	} else {
+		log.Println("[worker][AnswerSimilarityQueueConsumer][Consume] examID", examID)
+		err = consumer.analyticsService.DetectAnswerSimilarity(uint(examID))
+		if err != nil {
+			log.Println("[worker][AnswerSimilarityQueueConsumer][Consume] failed to detect answer similarity:", err.Error())
+		}
+	}
+	if err := delivery.Ack(); err != nil {
+		log.Println("[worker][AnswerSimilarityQueueConsumer][Consume] failed to ack:", err.Error())
+	}
+}
@@ -0,0 +1,113 @@
+package worker
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/analytics"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/examevent"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/questionpool"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/receipt"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
+)
+
+// ExpiredParticipantFinalizer submits the participants which ran out of time, so the database does not rely on the handlers' expiry checks.
+type ExpiredParticipantFinalizer struct {
+	participantService  participant.Service
+	questionPoolService questionpool.Service
+	submissionService   submission.Service
+	receiptService      receipt.Service
+	analyticsService    analytics.Service
+	examEventService    examevent.Service
+}
+
+func NewExpiredParticipantFinalizer(
+	participantService participant.Service,
+	questionPoolService questionpool.Service,
+	submissionService submission.Service,
+	receiptService receipt.Service,
+	analyticsService analytics.Service,
+	examEventService examevent.Service,
+) *ExpiredParticipantFinalizer {
+	return &ExpiredParticipantFinalizer{
+		participantService:  participantService,
+		questionPoolService: questionPoolService,
+		submissionService:   submissionService,
+		receiptService:      receiptService,
+		analyticsService:    analyticsService,
+		examEventService:    examEventService,
+	}
+}
+
+func (f *ExpiredParticipantFinalizer) Finalize() {
+	now := time.Now()
+	participants, err := f.participantService.GetExpiredParticipants(now)
+	if err != nil {
+		log.Println("[worker][ExpiredParticipantFinalizer][Finalize] failed to get expired participants:", err.Error())
+		return
+	}
+
+	finalizedCount := 0
+	for _, p := range participants {
+		// the answers are flushed before the participant is ended, so a failure leaves them expired to be picked up on the next tick
+		questionIDs, err := f.questionPoolService.GetServedQuestionIDs(p.ID, p.ExamID)
+		if err != nil {
+			log.Printf("[worker][ExpiredParticipantFinalizer][Finalize] failed to get served questions of participant %d: %s\n", p.ID, err.Error())
+			continue
+		}
+		err = f.submissionService.FlushAnswers(p.ID, questionIDs)
+		if err != nil {
+			log.Printf("[worker][ExpiredParticipantFinalizer][Finalize] failed to flush answers of participant %d: %s\n", p.ID, err.Error())
+			continue
+		}
+
+		// ended at the actual deadline rather than when the job noticed it
+		endedAt := p.Deadline(now)
+		err = f.participantService.EndParticipant(p.ID, endedAt)
+		if err != nil {
+			if !errors.Is(err, lib.ErrParticipantAlreadyEnded) {
+				log.Printf("[worker][ExpiredParticipantFinalizer][Finalize] failed to end participant %d: %s\n", p.ID, err.Error())
+			}
+			continue
+		}
+		p.EndedAt = &endedAt
+		finalizedCount++
+
+		// a receipt which fails here is issued by issueMissingReceipts on a later tick
+		if _, err := f.receiptService.IssueReceipt(p, questionIDs); err != nil {
+			log.Printf("[worker][ExpiredParticipantFinalizer][Finalize] failed to issue receipt of participant %d: %s\n", p.ID, err.Error())
+		}
+
+		f.analyticsService.InvalidateExamStatistics(p.ExamID)
+		f.examEventService.Publish(examevent.ExamSubmitted, p.ExamID, p.ID)
+	}
+	if finalizedCount > 0 {
+		log.Println("[worker][ExpiredParticipantFinalizer][Finalize] finalized expired participants:", finalizedCount)
+	}
+
+	f.issueMissingReceipts()
+}
+
+// issueMissingReceipts retries the receipts of the participants which were submitted or finalized while issuing their receipt failed.
+// Issuing flushes the answers still cached, so they reach the database before the cache drops them.
+func (f *ExpiredParticipantFinalizer) issueMissingReceipts() {
+	participants, err := f.receiptService.GetEndedParticipantsWithoutReceipt()
+	if err != nil {
+		log.Println("[worker][ExpiredParticipantFinalizer][issueMissingReceipts] failed to get participants without receipt:", err.Error())
+		return
+	}
+
+	for _, p := range participants {
+		questionIDs, err := f.questionPoolService.GetServedQuestionIDs(p.ID, p.ExamID)
+		if err != nil {
+			log.Printf("[worker][ExpiredParticipantFinalizer][issueMissingReceipts] failed to get served questions of participant %d: %s\n", p.ID, err.Error())
+			continue
+		}
+		if _, err := f.receiptService.IssueReceipt(p, questionIDs); err != nil {
+			log.Printf("[worker][ExpiredParticipantFinalizer][issueMissingReceipts] failed to issue receipt of participant %d: %s\n", p.ID, err.Error())
+		}
+	}
+}
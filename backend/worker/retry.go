@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/client/queue"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/deadletter"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+)
+
+// permanentErrors cannot be fixed by a retry nor a replay, so their deliveries are given up right away.
+var permanentErrors = []error{
+	lib.ErrSubmissionNotFound,
+	lib.ErrInvalidCachedAnswer,
+	lib.ErrSubmissionFlagNotFound,
+	lib.ErrInvalidCachedFlag,
+	lib.ErrAnswersFrozen,
+}
+
+func isPermanentError(err error) bool {
+	for _, permanentErr := range permanentErrors {
+		if errors.Is(err, permanentErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryWithBackoff calls fn until it succeeds, fails permanently, or maxAttempts is reached, doubling the backoff after each failure.
+func retryWithBackoff(maxAttempts int, backoff time.Duration, fn func() error) (int, error) {
+	attempts := 0
+	for {
+		attempts++
+		err := fn()
+		if err == nil {
+			return attempts, nil
+		}
+		log.Printf("[worker][retryWithBackoff] attempt %d failed: %s\n", attempts, err.Error())
+		if attempts >= maxAttempts || isPermanentError(err) {
+			return attempts, err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// deadLetterDelivery keeps a delivery which ran out of attempts as a dead letter to be replayed later, and acks it.
+// When the dead letter cannot be recorded either, the delivery is rejected, rejected deliveries are returned to the queue periodically by the worker service.
+func deadLetterDelivery(deadLetterService deadletter.Service, queueName string, delivery queue.Delivery, attempts int, err error) {
+	reason := fmt.Sprintf("failed after %d attempts: %s", attempts, err.Error())
+	if err := deadLetterService.RecordDeadLetter(queueName, delivery.Payload(), reason, attempts); err != nil {
+		if err := delivery.Reject(); err != nil {
+			log.Println("[worker][deadLetterDelivery] failed to reject delivery:", err.Error())
+		}
+		return
+	}
+	if err := delivery.Ack(); err != nil {
+		log.Println("[worker][deadLetterDelivery] failed to ack delivery:", err.Error())
+	}
+}
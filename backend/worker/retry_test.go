@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/deadletter"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/lib"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
+)
+
+var errTransient = errors.New("transient")
+
+type fakeDelivery struct {
+	payload  string
+	acked    int
+	rejected int
+}
+
+func (d *fakeDelivery) Payload() string {
+	return d.payload
+}
+
+func (d *fakeDelivery) Ack() error {
+	d.acked++
+	return nil
+}
+
+func (d *fakeDelivery) Reject() error {
+	d.rejected++
+	return nil
+}
+
+type deadLetter struct {
+	queueName string
+	payload   string
+	attempts  int
+}
+
+type fakeDeadLetterService struct {
+	deadletter.Service
+	err         error
+	deadLetters []deadLetter
+}
+
+func (s *fakeDeadLetterService) RecordDeadLetter(queueName string, payload string, reason string, attempts int) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.deadLetters = append(s.deadLetters, deadLetter{queueName: queueName, payload: payload, attempts: attempts})
+	return nil
+}
+
+type fakeSubmissionService struct {
+	submission.Service
+	errs  []error
+	calls int
+}
+
+func (s *fakeSubmissionService) UpsertFlagInDB(payload string) (*submission.ExamSessionFlagCacheObject, error) {
+	s.calls++
+	if s.calls <= len(s.errs) {
+		return nil, s.errs[s.calls-1]
+	}
+	return &submission.ExamSessionFlagCacheObject{}, nil
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "succeeds right away", wantAttempts: 1},
+		{name: "succeeds after failures", errs: []error{errTransient, errTransient}, wantAttempts: 3},
+		{name: "runs out of attempts", errs: []error{errTransient, errTransient, errTransient, errTransient}, wantAttempts: 3, wantErr: errTransient},
+		{name: "stops on a permanent error", errs: []error{errTransient, lib.ErrSubmissionNotFound, errTransient}, wantAttempts: 2, wantErr: lib.ErrSubmissionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			attempts, err := retryWithBackoff(3, 0, func() error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+			if attempts != tt.wantAttempts || calls != tt.wantAttempts {
+				t.Fatalf("attempts = %d, calls = %d, want %d", attempts, calls, tt.wantAttempts)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeadLetterDelivery(t *testing.T) {
+	t.Run("acks once recorded", func(t *testing.T) {
+		deadLetterService := &fakeDeadLetterService{}
+		delivery := &fakeDelivery{payload: "payload"}
+		deadLetterDelivery(deadLetterService, constants.UpdateFlagQueueName, delivery, 3, errTransient)
+
+		if delivery.acked != 1 || delivery.rejected != 0 {
+			t.Fatalf("acked = %d, rejected = %d, want 1 and 0", delivery.acked, delivery.rejected)
+		}
+		want := deadLetter{queueName: constants.UpdateFlagQueueName, payload: "payload", attempts: 3}
+		if len(deadLetterService.deadLetters) != 1 || deadLetterService.deadLetters[0] != want {
+			t.Fatalf("dead letters = %v, want [%v]", deadLetterService.deadLetters, want)
+		}
+	})
+
+	t.Run("rejects when it cannot be recorded", func(t *testing.T) {
+		deadLetterService := &fakeDeadLetterService{err: errTransient}
+		delivery := &fakeDelivery{payload: "payload"}
+		deadLetterDelivery(deadLetterService, constants.UpdateFlagQueueName, delivery, 3, errTransient)
+
+		if delivery.acked != 0 || delivery.rejected != 1 {
+			t.Fatalf("acked = %d, rejected = %d, want 0 and 1", delivery.acked, delivery.rejected)
+		}
+	})
+}
+
+func TestUpdateFlagQueueConsumerConsume(t *testing.T) {
+	cfg := &config.Config{UpdateFlagMaxAttempts: 2}
+	tests := []struct {
+		name            string
+		errs            []error
+		wantCalls       int
+		wantAcked       int
+		wantDeadLetters int
+	}{
+		{name: "writes the flag", wantCalls: 1, wantAcked: 1},
+		{name: "retries a transient error", errs: []error{errTransient}, wantCalls: 2, wantAcked: 1},
+		{name: "drops a permanent error", errs: []error{lib.ErrSubmissionFlagNotFound}, wantCalls: 1, wantAcked: 1},
+		{name: "dead-letters after the last attempt", errs: []error{errTransient, errTransient}, wantCalls: 2, wantAcked: 1, wantDeadLetters: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submissionService := &fakeSubmissionService{errs: tt.errs}
+			deadLetterService := &fakeDeadLetterService{}
+			delivery := &fakeDelivery{payload: "payload"}
+			NewUpdateFlagQueueConsumer(cfg, submissionService, deadLetterService).Consume(delivery)
+
+			if submissionService.calls != tt.wantCalls {
+				t.Fatalf("calls = %d, want %d", submissionService.calls, tt.wantCalls)
+			}
+			if delivery.acked != tt.wantAcked || delivery.rejected != 0 {
+				t.Fatalf("acked = %d, rejected = %d, want %d and 0", delivery.acked, delivery.rejected, tt.wantAcked)
+			}
+			if len(deadLetterService.deadLetters) != tt.wantDeadLetters {
+				t.Fatalf("dead letters = %d, want %d", len(deadLetterService.deadLetters), tt.wantDeadLetters)
+			}
+		})
+	}
+}
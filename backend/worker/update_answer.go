@@ -3,31 +3,69 @@ package worker
 import (
 	"log"
 
-	rmq "github.com/adjust/rmq/v5"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/client/queue"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/deadletter"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/examevent"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/participant"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
 )
 
 type UpdateAnswerQueueConsumer struct {
-	submissionService submission.Service
+	cfg                *config.Config
+	submissionService  submission.Service
+	participantService participant.Service
+	examEventService   examevent.Service
+	deadLetterService  deadletter.Service
 }
 
 func NewUpdateAnswerQueueConsumer(
+	cfg *config.Config,
 	submissionService submission.Service,
+	participantService participant.Service,
+	examEventService examevent.Service,
+	deadLetterService deadletter.Service,
 ) *UpdateAnswerQueueConsumer {
 	return &UpdateAnswerQueueConsumer{
-		submissionService: submissionService,
+		cfg:                cfg,
+		submissionService:  submissionService,
+		participantService: participantService,
+		examEventService:   examEventService,
+		deadLetterService:  deadLetterService,
 	}
 }
 
-func (consumer *UpdateAnswerQueueConsumer) Consume(delivery rmq.Delivery) {
-	redisKey := delivery.Payload()
+func (consumer *UpdateAnswerQueueConsumer) Consume(delivery queue.Delivery) {
+	payload := delivery.Payload()
 
-	log.Println("[worker][UpdateAnswerQueueConsumer][Consume] redisKey", redisKey)
-	err := consumer.submissionService.UpsertSubmissionInDB(redisKey)
+	log.Println("[worker][UpdateAnswerQueueConsumer][Consume] payload", payload)
+	var cacheObject *submission.ExamSessionSubmissionCacheObject
+	attempts, err := retryWithBackoff(consumer.cfg.UpdateAnswerMaxAttempts, consumer.cfg.UpdateAnswerRetryBackoff, func() error {
+		var err error
+		cacheObject, err = consumer.submissionService.UpsertSubmissionInDB(payload)
+		return err
+	})
+	if err != nil && isPermanentError(err) {
+		// a legacy key payload whose answer left the cache before it was written, or an answer arriving after the receipt
+		log.Println("[worker][UpdateAnswerQueueConsumer][Consume] dropping delivery:", err.Error())
+		if err := delivery.Ack(); err != nil {
+			log.Println("[worker][UpdateAnswerQueueConsumer][Consume] failed to ack delivery:", err.Error())
+		}
+		return
+	}
 	if err != nil {
-		// TODO: retry
+		// the published answer is kept to be replayed once the database recovers
+		deadLetterDelivery(consumer.deadLetterService, constants.UpdateAnswerQueueName, delivery, attempts, err)
+		return
+	}
+
+	// the answer is only published once it is in the database, so the proctor dashboard counts it
+	participant, err := consumer.participantService.GetParticipantByID(cacheObject.ParticipantID)
+	if err == nil {
+		consumer.examEventService.Publish(examevent.AnswerSubmitted, participant.ExamID, participant.ID)
 	}
 	if err := delivery.Ack(); err != nil {
-		// TODO: handle ack error
+		log.Println("[worker][UpdateAnswerQueueConsumer][Consume] failed to ack delivery:", err.Error())
 	}
 }
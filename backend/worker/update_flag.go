@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"log"
+
+	"github.com/prajnapras19/project-form-exam-sman2/backend/client/queue"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/deadletter"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/submission"
+)
+
+type UpdateFlagQueueConsumer struct {
+	cfg               *config.Config
+	submissionService submission.Service
+	deadLetterService deadletter.Service
+}
+
+func NewUpdateFlagQueueConsumer(
+	cfg *config.Config,
+	submissionService submission.Service,
+	deadLetterService deadletter.Service,
+) *UpdateFlagQueueConsumer {
+	return &UpdateFlagQueueConsumer{
+		cfg:               cfg,
+		submissionService: submissionService,
+		deadLetterService: deadLetterService,
+	}
+}
+
+func (consumer *UpdateFlagQueueConsumer) Consume(delivery queue.Delivery) {
+	payload := delivery.Payload()
+
+	log.Println("[worker][UpdateFlagQueueConsumer][Consume] payload", payload)
+	attempts, err := retryWithBackoff(consumer.cfg.UpdateFlagMaxAttempts, consumer.cfg.UpdateFlagRetryBackoff, func() error {
+		_, err := consumer.submissionService.UpsertFlagInDB(payload)
+		return err
+	})
+	if err != nil && isPermanentError(err) {
+		// only a legacy key payload can fail this way, its flag left the cache before it was written
+		log.Println("[worker][UpdateFlagQueueConsumer][Consume] dropping delivery:", err.Error())
+		if err := delivery.Ack(); err != nil {
+			log.Println("[worker][UpdateFlagQueueConsumer][Consume] failed to ack delivery:", err.Error())
+		}
+		return
+	}
+	if err != nil {
+		deadLetterDelivery(consumer.deadLetterService, constants.UpdateFlagQueueName, delivery, attempts, err)
+		return
+	}
+	if err := delivery.Ack(); err != nil {
+		log.Println("[worker][UpdateFlagQueueConsumer][Consume] failed to ack delivery:", err.Error())
+	}
+}
@@ -1,11 +1,13 @@
 package worker
 
 import (
+	"log"
 	"time"
 
-	rmq "github.com/adjust/rmq/v5"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/client/queue"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/config"
 	"github.com/prajnapras19/project-form-exam-sman2/backend/constants"
+	"github.com/prajnapras19/project-form-exam-sman2/backend/reconciliation"
 )
 
 type Service interface {
@@ -13,24 +15,90 @@ type Service interface {
 }
 
 type service struct {
-	cfg                       *config.Config
-	updateAnswerQueue         rmq.Queue
-	updateAnswerQueueConsumer *UpdateAnswerQueueConsumer
+	cfg                           *config.Config
+	updateAnswerQueue             queue.Queue
+	updateAnswerQueueConsumer     *UpdateAnswerQueueConsumer
+	updateFlagQueue               queue.Queue
+	updateFlagQueueConsumer       *UpdateFlagQueueConsumer
+	answerSimilarityQueue         queue.Queue
+	answerSimilarityQueueConsumer *AnswerSimilarityQueueConsumer
+	reconciliationService         reconciliation.Service
+	expiredParticipantFinalizer   *ExpiredParticipantFinalizer
 }
 
 func NewService(
 	cfg *config.Config,
-	updateAnswerQueue rmq.Queue,
+	updateAnswerQueue queue.Queue,
 	updateAnswerQueueConsumer *UpdateAnswerQueueConsumer,
+	updateFlagQueue queue.Queue,
+	updateFlagQueueConsumer *UpdateFlagQueueConsumer,
+	answerSimilarityQueue queue.Queue,
+	answerSimilarityQueueConsumer *AnswerSimilarityQueueConsumer,
+	reconciliationService reconciliation.Service,
+	expiredParticipantFinalizer *ExpiredParticipantFinalizer,
 ) Service {
 	return &service{
-		cfg:                       cfg,
-		updateAnswerQueue:         updateAnswerQueue,
-		updateAnswerQueueConsumer: updateAnswerQueueConsumer,
+		cfg:                           cfg,
+		updateAnswerQueue:             updateAnswerQueue,
+		updateAnswerQueueConsumer:     updateAnswerQueueConsumer,
+		updateFlagQueue:               updateFlagQueue,
+		updateFlagQueueConsumer:       updateFlagQueueConsumer,
+		answerSimilarityQueue:         answerSimilarityQueue,
+		answerSimilarityQueueConsumer: answerSimilarityQueueConsumer,
+		reconciliationService:         reconciliationService,
+		expiredParticipantFinalizer:   expiredParticipantFinalizer,
 	}
 }
 
 func (s *service) InitConsumers() {
 	s.updateAnswerQueue.StartConsuming(s.cfg.UpdateAnswerQueuePrefetchLimit, time.Second)
 	s.updateAnswerQueue.AddConsumer(constants.UpdateAnswerConsumerName, s.updateAnswerQueueConsumer)
+
+	s.updateFlagQueue.StartConsuming(s.cfg.UpdateFlagQueuePrefetchLimit, time.Second)
+	s.updateFlagQueue.AddConsumer(constants.UpdateFlagConsumerName, s.updateFlagQueueConsumer)
+
+	s.answerSimilarityQueue.StartConsuming(s.cfg.AnswerSimilarityQueuePrefetchLimit, time.Second)
+	s.answerSimilarityQueue.AddConsumer(constants.AnswerSimilarityConsumerName, s.answerSimilarityQueueConsumer)
+
+	go s.returnRejectedDeliveries()
+	go s.reconcileSubmissions()
+	go s.finalizeExpiredParticipants()
+}
+
+// returnRejectedDeliveries moves deliveries which could not be acked nor dead-lettered back to the ready queues
+func (s *service) returnRejectedDeliveries() {
+	ticker := time.NewTicker(s.cfg.RejectedDeliveryReturnInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		for _, q := range []queue.Queue{s.updateAnswerQueue, s.updateFlagQueue} {
+			count, err := q.ReturnRejected(constants.RejectedDeliveryReturnBatchSize)
+			if err != nil {
+				log.Println("[worker][service][returnRejectedDeliveries] failed to return rejected deliveries:", err.Error())
+				continue
+			}
+			if count > 0 {
+				log.Println("[worker][service][returnRejectedDeliveries] returned rejected deliveries:", count)
+			}
+		}
+	}
+}
+
+// reconcileSubmissions flushes the cached answers into the database on start, and then periodically, so answers are not lost when the queue falls behind
+func (s *service) reconcileSubmissions() {
+	ticker := time.NewTicker(s.cfg.SubmissionReconciliationInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.reconciliationService.ReconcileAll(); err != nil {
+			log.Println("[worker][service][reconcileSubmissions] failed to reconcile submissions:", err.Error())
+		}
+		<-ticker.C
+	}
+}
+
+func (s *service) finalizeExpiredParticipants() {
+	ticker := time.NewTicker(s.cfg.ExpiredParticipantFinalizationInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.expiredParticipantFinalizer.Finalize()
+	}
 }
@@ -123,7 +123,9 @@ const EditExam = (props) => {
   const handleSubmit = async (e) => {
     e.preventDefault();
 
+    // keep the fields that are not editable in this form, e.g. the scoring policy
     const customObject = {
+      ...fetchedExam,
       ...formData,
     };
 
@@ -235,6 +235,8 @@ const ReadParticipants = (props) => {
                 <th>Sisa Waktu</th>
                 <th>Status</th>
                 <th>Total Poin</th>
+                <th>Nilai</th>
+                <th>Predikat</th>
                 <th colSpan="3">Aksi</th>
               </tr>
             </thead>
@@ -289,6 +291,19 @@ const ReadParticipants = (props) => {
                   <td>
                     {participant.total_point}
                   </td>
+                  <td>
+                    {participant.normalized_score ?? '-'}
+                    {
+                      participant.is_passed === null
+                      ? null
+                      : participant.is_passed
+                      ? (<span> (lulus)</span>)
+                      : (<span> (tidak lulus)</span>)
+                    }
+                  </td>
+                  <td>
+                    {participant.grade || '-'}
+                  </td>
                   <td>
                     <Button variant="primary" className="me-3" onClick={() => navigate(`/admin/exams/${exam.serial}/participants/${participant.id}/edit`)}>Ubah</Button>
                   </td>
@@ -1,4 +1,4 @@
-import React, { useEffect, useState } from 'react';
+import React, { useEffect, useRef, useState } from 'react';
 import { useNavigate, useParams } from 'react-router-dom';
 import InternalServerErrorPage from '../etc/500';
 import { Button, Container, Spinner, Form, Row, Col } from 'react-bootstrap';
@@ -25,6 +25,8 @@ const ExamSession = () => {
   const [disableChangeQuestion, setDisableChangeQuestion] = useState(false);
   const [startTime, setStartTime] = useState(null);
   const [duration, setDuration] = useState(null);
+  // answers are ordered by this counter, which continues from the highest sequence the server has
+  const answerSequence = useRef(null);
 
   const [loadingSubmit, setLoadingSubmit] = useState(false);
   const [isSubmitted, setIsSubmitted] = useState(false);
@@ -180,6 +182,19 @@ const ExamSession = () => {
     }
   }
   
+  const getNextAnswerSequence = async (token) => {
+    if (answerSequence.current === null) {
+      const response = await axios.get(`${process.env.REACT_APP_BACKEND_URL}/api/v1/exam-session/${examSerial}/summary`, {
+        headers: {
+          'Authorization': `Bearer ${token}`
+        },
+      });
+      answerSequence.current = Math.max(0, ...response.data.data.questions.map(question => question.sequence));
+    }
+    answerSequence.current += 1;
+    return answerSequence.current;
+  }
+
   const handleClickOption = async (optionId) => {
     setDisableChooseOption(true);
     try {
@@ -189,9 +204,11 @@ const ExamSession = () => {
         navigate('/404');
       }
 
+      const sequence = await getNextAnswerSequence(token);
       await axios.post(`${process.env.REACT_APP_BACKEND_URL}/api/v1/exam-session/${examSerial}/questions/${currentQuestion.question.id}`,
         {
           mcq_option_id: optionId,
+          sequence: sequence,
         },
         {
           headers: {